@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spy16/parens/format"
+)
+
+// runFmt implements 'parens fmt [-w] [-d] [files...]'. Source is read from
+// stdin when no files are given. Returns the exit code.
+func runFmt(args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	write := flags.Bool("w", false, "Write result to the source file instead of stdout")
+	diff := flags.Bool("d", false, "Display diffs instead of rewriting files")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: parens fmt [-w] [-d] [files...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *diff && *write {
+		fmt.Fprintln(os.Stderr, "error: cannot use -d with -w")
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		if *write {
+			fmt.Fprintln(os.Stderr, "error: cannot use -w with standard input")
+			return 2
+		}
+		if err := fmtSource("<stdin>", os.Stdin, *diff, false); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %s\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	code := 0
+	for _, file := range flags.Args() {
+		if err := fmtFile(file, *diff, *write); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %s\n", err)
+			code = 1
+		}
+	}
+	return code
+}
+
+func fmtFile(file string, diff, write bool) error {
+	fh, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	return fmtSource(file, fh, diff, write)
+}
+
+func fmtSource(name string, r io.Reader, diff, write bool) error {
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	res, err := format.Source(name, src)
+	if err != nil {
+		return err
+	}
+
+	if diff {
+		if !bytes.Equal(src, res) {
+			fmt.Print(unifiedDiff(name, string(src), string(res)))
+		}
+		return nil
+	}
+
+	if write {
+		if bytes.Equal(src, res) {
+			return nil
+		}
+		info, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(name, res, info.Mode().Perm())
+	}
+
+	_, err = os.Stdout.Write(res)
+	return err
+}
+
+// unifiedDiff returns the line diff between a and b in unified format
+// with 3 lines of context.
+func unifiedDiff(name, a, b string) string {
+	const context = 3
+
+	ops := diffLines(splitLines(a), splitLines(b))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s.orig\n+++ %s\n", name, name)
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		// extend the hunk till there are more than 2*context unchanged
+		// lines between the changes.
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next == len(ops) || next-end > 2*context {
+				break
+			}
+			end = next
+		}
+		stop := end + context
+		if stop > len(ops) {
+			stop = len(ops)
+		}
+
+		aLine, aCount, bLine, bCount := 0, 0, 0, 0
+		for j := start; j < stop; j++ {
+			if j == start {
+				aLine, bLine = ops[j].aLine, ops[j].bLine
+			}
+			if ops[j].kind != '+' {
+				aCount++
+			}
+			if ops[j].kind != '-' {
+				bCount++
+			}
+		}
+
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", aLine, aCount, bLine, bCount)
+		for j := start; j < stop; j++ {
+			fmt.Fprintf(&sb, "%c%s\n", ops[j].kind, ops[j].text)
+		}
+		i = stop
+	}
+
+	return sb.String()
+}
+
+// diffOp is a single line of the diff. kind is one of ' ', '-' and '+'.
+// aLine and bLine are 1-based line numbers in the respective sources.
+type diffOp struct {
+	kind         byte
+	text         string
+	aLine, bLine int
+}
+
+// diffLines computes the line diff using the linear space variation of
+// Myers' O(ND) difference algorithm.
+func diffLines(a, b []string) []diffOp {
+	d := &differ{a: a, b: b}
+	d.diff(0, len(a), 0, len(b))
+	return d.ops
+}
+
+type differ struct {
+	a, b []string
+	ops  []diffOp
+}
+
+// diff appends the operations converting a[aStart:aEnd] to b[bStart:bEnd].
+func (d *differ) diff(aStart, aEnd, bStart, bEnd int) {
+	for aStart < aEnd && bStart < bEnd && d.a[aStart] == d.b[bStart] {
+		d.emit(' ', aStart, bStart)
+		aStart++
+		bStart++
+	}
+
+	suffix := 0
+	for aEnd-suffix > aStart && bEnd-suffix > bStart && d.a[aEnd-suffix-1] == d.b[bEnd-suffix-1] {
+		suffix++
+	}
+	aEnd -= suffix
+	bEnd -= suffix
+
+	switch {
+	case aStart == aEnd:
+		for j := bStart; j < bEnd; j++ {
+			d.emit('+', aStart, j)
+		}
+
+	case bStart == bEnd:
+		for i := aStart; i < aEnd; i++ {
+			d.emit('-', i, bStart)
+		}
+
+	default:
+		x, y := d.bisect(aStart, aEnd, bStart, bEnd)
+		d.diff(aStart, x, bStart, y)
+		d.diff(x, aEnd, y, bEnd)
+	}
+
+	for k := 0; k < suffix; k++ {
+		d.emit(' ', aEnd+k, bEnd+k)
+	}
+}
+
+// bisect finds the middle snake of the shortest edit script by searching
+// forward from the start and backward from the end simultaneously. Returns
+// the point at which the search paths overlap which splits the problem
+// into two smaller ones.
+func (d *differ) bisect(aStart, aEnd, bStart, bEnd int) (int, int) {
+	a, b := d.a[aStart:aEnd], d.b[bStart:bEnd]
+	n, m := len(a), len(b)
+
+	maxD := (n + m + 1) / 2
+	offset := maxD
+	fwd, rev := make([]int, 2*maxD+2), make([]int, 2*maxD+2)
+	for i := range fwd {
+		fwd[i], rev[i] = -1, -1
+	}
+	fwd[offset+1], rev[offset+1] = 0, 0
+
+	delta := n - m
+	// paths overlap first in the forward search if delta is odd.
+	front := delta%2 != 0
+
+	// diagonals that ran off the edges are skipped in later rounds.
+	fStart, fEnd, rStart, rEnd := 0, 0, 0, 0
+
+	for D := 0; D < maxD; D++ {
+		for k := -D + fStart; k <= D-fEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -D || (k != D && fwd[i-1] < fwd[i+1]) {
+				x = fwd[i+1]
+			} else {
+				x = fwd[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			fwd[i] = x
+
+			if x > n {
+				fEnd += 2
+			} else if y > m {
+				fStart += 2
+			} else if front {
+				if r := offset + delta - k; r >= 0 && r < len(rev) && rev[r] != -1 && x >= n-rev[r] {
+					return aStart + x, bStart + y
+				}
+			}
+		}
+
+		for k := -D + rStart; k <= D-rEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -D || (k != D && rev[i-1] < rev[i+1]) {
+				x = rev[i+1]
+			} else {
+				x = rev[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x++
+				y++
+			}
+			rev[i] = x
+
+			if x > n {
+				rEnd += 2
+			} else if y > m {
+				rStart += 2
+			} else if !front {
+				if f := offset + delta - k; f >= 0 && f < len(fwd) && fwd[f] != -1 {
+					fx := fwd[f]
+					if fx >= n-x {
+						return aStart + fx, bStart + fx - (f - offset)
+					}
+				}
+			}
+		}
+	}
+
+	// no overlap. not expected but deleting all of a and inserting all of
+	// b is still a valid diff.
+	return aEnd, bStart
+}
+
+func (d *differ) emit(kind byte, i, j int) {
+	text := ""
+	if kind == '+' {
+		text = d.b[j]
+	} else {
+		text = d.a[i]
+	}
+	d.ops = append(d.ops, diffOp{kind: kind, text: text, aLine: i + 1, bLine: j + 1})
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
@@ -8,9 +8,14 @@ import (
 	"strings"
 
 	"github.com/spy16/parens"
+	"github.com/spy16/parens/parser"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "fmt" {
+		os.Exit(runFmt(os.Args[2:]))
+	}
+
 	var src string
 	flag.StringVar(&src, "e", "", "Execute source passed in as argument")
 	flag.Parse()
@@ -24,6 +29,8 @@ func main() {
 		execString(src, exec)
 	} else if len(os.Args) == 2 {
 		execFile(exec)
+	} else if isPiped(os.Stdin) {
+		execStdin(exec)
 	} else {
 		runREPL(ctx, exec)
 	}
@@ -37,7 +44,7 @@ func execString(src string, exec *parens.Interpreter) {
 		os.Exit(1)
 	}
 
-	fmt.Println(val)
+	fmt.Println(parser.Repr(val))
 	return
 }
 
@@ -50,6 +57,25 @@ func execFile(exec *parens.Interpreter) {
 	return
 }
 
+func execStdin(exec *parens.Interpreter) {
+	_, err := exec.ExecuteReader("<stdin>", os.Stdin)
+	if err != nil {
+		fmt.Printf("error: %s\n", err)
+		os.Exit(1)
+	}
+	return
+}
+
+// isPiped returns true if the file is not a terminal (e.g. when the
+// source is piped in using 'cat forms | parens').
+func isPiped(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice == 0
+}
+
 func runREPL(ctx context.Context, exec *parens.Interpreter) {
 	exec.DefaultSource = "<REPL>"
 	repl, err := parens.NewREPL(exec)
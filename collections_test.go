@@ -0,0 +1,283 @@
+package parens_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/spy16/parens"
+	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/stdlib"
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_Collections(suite *testing.T) {
+	suite.Parallel()
+
+	cases := map[string]interface{}{
+		`[1 "a" [2]]`:                         vecOf(int64(1), "a", vecOf(int64(2))),
+		`#{1 [2]}`:                            setOf(int64(1), vecOf(int64(2))),
+		`(vector 1 2)`:                        vecOf(int64(1), int64(2)),
+		`(vector)`:                            vecOf(),
+		`(hash-map :a 1 :b 2)`:                mapOf(kw(":a"), int64(1), kw(":b"), int64(2)),
+		`(hash-set 1 2 1)`:                    setOf(int64(1), int64(2)),
+		`(vec #{1 2})`:                        vecOf(int64(1), int64(2)),
+		`(vec {:a 1})`:                        vecOf(vecOf(kw(":a"), int64(1))),
+		`(vec (make-slice "int" 2))`:          vecOf(0, 0),
+		`(set [1 2 1])`:                       setOf(int64(1), int64(2)),
+		`(vector? [1])`:                       true,
+		`(vector? (make-slice "int" 1))`:      false,
+		`(map? {})`:                           true,
+		`(set? #{})`:                          true,
+		`(set? [])`:                           false,
+		`(== [1 [2]] [1 [2]])`:                true,
+		`(== [1 2] [2 1])`:                    false,
+		`(== {:a 1 :b 2} {:b 2 :a 1})`:        true,
+		`(== #{1 2} #{2 1})`:                  true,
+		`(== [1] #{1})`:                       false,
+		`(apply + [1 2 3])`:                   int64(6),
+		`(:b {[1] :x :b #{1}})`:               setOf(int64(1)),
+		`(pr-str [1 #{:a} {[1] "x"}])`:        `[1 #{:a} {[1] "x"}]`,
+		`(pr-str (vec (make-slice "int" 2)))`: `[0 0]`,
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			res, err := executeWithStdlib(t, src)
+			require.NoError(t, err)
+			assert.Equal(t, expected, res)
+		})
+	}
+
+	suite.Run("Persistent", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		require.NoError(t, stdlib.RegisterAll(scope))
+		scope.Bind("clobber", func(items []interface{}, m map[string]interface{}) int {
+			items[0] = "changed"
+			m[":a"] = "changed"
+			return len(items)
+		})
+
+		res, err := parens.New(scope).Execute(`(do (label v [1 2]) (label m {:a 1}) (clobber v m) [v m])`)
+		require.NoError(t, err)
+		assert.Equal(t, "[[1 2] {:a 1}]", parser.Repr(res))
+	})
+
+	suite.Run("Errors", func(t *testing.T) {
+		for _, src := range []string{
+			`(hash-map :a)`,
+			`(hash-set (make-slice "int" 1))`,
+			`(vec 10)`,
+			`(set [[1] (make-slice "int" 1)])`,
+		} {
+			_, err := executeWithStdlib(t, src)
+			assert.Error(t, err, src)
+		}
+	})
+}
+
+func TestExecute_CollectionFunctions(suite *testing.T) {
+	suite.Parallel()
+
+	cases := map[string]string{
+		`(map + [1 2 3] [10 20])`:                          `(11 22)`,
+		`(map vector (range) "ab")`:                        `([0 \a] [1 \b])`,
+		`(reduce + [1 2 3])`:                               `6`,
+		`(reduce + 10 [1 2 3])`:                            `16`,
+		`(reduce + [])`:                                    `0`,
+		`(reduce conj [] '(1 2))`:                          `[1 2]`,
+		`(into [1] '(2 3))`:                                `[1 2 3]`,
+		`(into {} [[:a 1] [:b 2]])`:                        `{:a 1 :b 2}`,
+		`(into #{} [1 1 2])`:                               `#{1 2}`,
+		`(into '() [1 2])`:                                 `(2 1)`,
+		`(conj [1] 2 3)`:                                   `[1 2 3]`,
+		`(conj {:a 1} [:b 2])`:                             `{:a 1 :b 2}`,
+		`(assoc {:a 1} :b 2 :a 3)`:                         `{:a 3 :b 2}`,
+		`(assoc [1 2] 0 :x 2 :y)`:                          `[:x 2 :y]`,
+		`(dissoc {:a 1 :b 2 :c 3} :a :c :d)`:               `{:b 2}`,
+		`(get {:a 1} :a)`:                                  `1`,
+		`(get {:a 1} :b)`:                                  `nil`,
+		`(get {:a 1} :b 0)`:                                `0`,
+		`(get [1 2] 1)`:                                    `2`,
+		`(get [1 2] 5 :none)`:                              `:none`,
+		`(get #{:a} :a)`:                                   `:a`,
+		`(get-in {:a [{:b 1}]} [:a 0 :b])`:                 `1`,
+		`(get-in {:a [1]} [:a 3] :none)`:                   `:none`,
+		`(update-in {:a {:b 1}} [:a :b] + 10)`:             `{:a {:b 11}}`,
+		`(update-in {:a [1 2]} [:a 1] #(* % 5))`:           `{:a [1 10]}`,
+		`(update-in {} [:a :b] vector)`:                    `{:a {:b [nil]}}`,
+		`(keys {:a 1 :b 2})`:                               `(:a :b)`,
+		`(vals {:a 1 :b 2})`:                               `(1 2)`,
+		`(keys {})`:                                        `()`,
+		`[(count [1 2]) (count {:a 1}) (count "héllo")]`:   `[2 1 5]`,
+		`[(count '()) (count (range 4)) (count #{1 2 3})]`: `[0 4 3]`,
+		`(first [1 2])`:                                    `1`,
+		`(rest [1 2 3])`:                                   `(2 3)`,
+		`(nth [1 2 3] 2)`:                                  `3`,
+		`(nth (range) 100)`:                                `100`,
+		`(nth "abc" 1)`:                                    `\b`,
+		`(nth '(1) 5 :none)`:                               `:none`,
+		`(concat [1 2] '(3) {:a 4})`:                       `(1 2 3 [:a 4])`,
+		`(take 4 (concat [:a] (range)))`:                   `(:a 0 1 2)`,
+		`(sort [3 1.5 2 -1])`:                              `(-1 1.5 2 3)`,
+		`(sort ["b" "c" "a"])`:                             `("a" "b" "c")`,
+		`(sort "cab")`:                                     `(\a \b \c)`,
+		`(sort [:b :a])`:                                   `(:a :b)`,
+		`(sort [[1 2] [1] [0 5]])`:                         `([0 5] [1] [1 2])`,
+		`(sort > [1 3 2])`:                                 `(3 2 1)`,
+		`(sort #(- %2 %1) [1 3 2])`:                        `(3 2 1)`,
+		`(sort-by count ["abc" "a" "ab"])`:                 `("a" "ab" "abc")`,
+		`(sort-by first > [[1 :a] [2 :b] [1 :c]])`:         `([2 :b] [1 :a] [1 :c])`,
+		`(group-by #(mod % 3) [1 2 3 4 5 6])`:              `{1 [1 4] 2 [2 5] 0 [3 6]}`,
+		`(frequencies [:a :b :a "x"])`:                     `{:a 2 :b 1 "x" 1}`,
+		`(partition 2 [1 2 3 4 5])`:                        `((1 2) (3 4))`,
+		`(partition 3 1 [1 2 3 4])`:                        `((1 2 3) (2 3 4))`,
+		`(take 2 (partition 2 (range)))`:                   `((0 1) (2 3))`,
+		`(distinct [1 2 1 [3] [3] 2])`:                     `(1 2 [3])`,
+		`(take 3 (distinct (map #(mod % 4) (range))))`:     `(0 1 2)`,
+		`(zipmap [:a :b :c] [1 2])`:                        `{:a 1 :b 2}`,
+		`(zipmap [:a :b] (range))`:                         `{:a 0 :b 1}`,
+		`(conj nil 1 2)`:                                   `(2 1)`,
+		`(count nil)`:                                      `0`,
+		`(first nil)`:                                      `nil`,
+		`(rest nil)`:                                       `()`,
+		`(assoc nil :a 1)`:                                 `{:a 1}`,
+		`(dissoc nil :a)`:                                  `nil`,
+		`(get nil :a)`:                                     `nil`,
+		`(get nil :a 0)`:                                   `0`,
+		`(get-in nil [:a :b])`:                             `nil`,
+		`(get-in {:a nil} [:a :b] 0)`:                      `0`,
+		`(update-in nil [:a] vector)`:                      `{:a [nil]}`,
+		`(keys nil)`:                                       `()`,
+		`(into nil [1 2])`:                                 `(2 1)`,
+		`(concat nil [1])`:                                 `(1)`,
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			res, err := executeWithStdlib(t, src)
+			require.NoError(t, err)
+			assert.Equal(t, expected, parser.Repr(res))
+		})
+	}
+
+	suite.Run("GoValues", func(t *testing.T) {
+		nums := []int{3, 1, 2}
+		ages := map[string]int{"bob": 30}
+
+		scope := parens.NewScope(nil)
+		require.NoError(t, stdlib.RegisterAll(scope))
+		scope.Bind("nums", nums)
+		scope.Bind("ages", ages)
+
+		cases := map[string]interface{}{
+			`(conj nums 4 5)`:              []int{3, 1, 2, 4, 5},
+			`(assoc nums 0 10 3 20)`:       []int{10, 1, 2, 20},
+			`(into nums [7])`:              []int{3, 1, 2, 7},
+			`(assoc ages "alice" 25)`:      map[string]int{"bob": 30, "alice": 25},
+			`(conj ages ["eve" 20])`:       map[string]int{"bob": 30, "eve": 20},
+			`(dissoc ages "bob")`:          map[string]int{},
+			`(update-in ages ["bob"] + 1)`: map[string]int{"bob": 31},
+			`(get ages "bob")`:             30,
+			`(get ages :bob 0)`:            int64(0),
+			`(get nums 1)`:                 1,
+			`(nth nums 2)`:                 2,
+			`(count nums)`:                 int64(3),
+			`(count ages)`:                 int64(1),
+			`(keys ages)`:                  value.NewList("bob"),
+			`(vals ages)`:                  value.NewList(30),
+			`(reduce + nums)`:              int64(6),
+			`(vec (sort nums))`:            vecOf(1, 2, 3),
+		}
+
+		for src, expected := range cases {
+			res, err := parens.New(scope).Execute(src)
+			require.NoError(t, err, src)
+			assert.Equal(t, expected, res, src)
+		}
+
+		// Go collections are copied, never modified in place.
+		assert.Equal(t, []int{3, 1, 2}, nums)
+		assert.Equal(t, map[string]int{"bob": 30}, ages)
+	})
+
+	suite.Run("ComparatorCalls", func(t *testing.T) {
+		nums := []int64{5, 3, 8, 1, 9, 2, 7, 3, 6, 4, 0, 8}
+
+		// comparator is called once per comparison made by the sort.
+		expected := 0
+		sorted := append([]int64(nil), nums...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			expected++
+			return sorted[i] < sorted[j]
+		})
+
+		calls := 0
+		scope := parens.NewScope(nil)
+		require.NoError(t, stdlib.RegisterAll(scope))
+		scope.Bind("nums", value.FromGo(nums))
+		scope.Bind("less", func(a, b int64) bool {
+			calls++
+			return a < b
+		})
+
+		res, err := parens.New(scope).Execute(`(sort less nums)`)
+		require.NoError(t, err)
+		assert.Equal(t, "(0 1 2 3 3 4 5 6 7 8 8 9)", parser.Repr(res))
+		assert.Equal(t, expected, calls)
+	})
+
+	suite.Run("GoFunctionResults", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		require.NoError(t, stdlib.RegisterAll(scope))
+		scope.Bind("nums", func() []int { return []int{3, 1, 2} })
+		scope.Bind("ages", func() map[string]int { return map[string]int{"bob": 30} })
+
+		cases := map[string]interface{}{
+			`(nums)`:                 vecOf(3, 1, 2),
+			`(conj (nums) 4)`:        vecOf(3, 1, 2, int64(4)),
+			`(ages)`:                 mapOf("bob", 30),
+			`(assoc (ages) :eve 20)`: mapOf("bob", 30, kw(":eve"), int64(20)),
+			`(get (ages) "bob")`:     30,
+			`(pr-str (nums) (ages))`: `[3 1 2] {"bob" 30}`,
+		}
+
+		for src, expected := range cases {
+			res, err := parens.New(scope).Execute(src)
+			require.NoError(t, err, src)
+			assert.Equal(t, expected, res, src)
+		}
+	})
+
+	suite.Run("Errors", func(t *testing.T) {
+		for _, src := range []string{
+			`(map +)`,
+			`(reduce + 1)`,
+			`(into [] 1)`,
+			`(conj 1 2)`,
+			`(conj {} [1])`,
+			`(conj (make-slice "int" 0) "a")`,
+			`(assoc {} :a)`,
+			`(assoc [1] 5 2)`,
+			`(assoc [1] :a 2)`,
+			`(assoc "a" 0 1)`,
+			`(dissoc [1] 0)`,
+			`(keys [1])`,
+			`(count 1)`,
+			`(nth [1] 5)`,
+			`(nth [1] :a)`,
+			`(concat [1] 2)`,
+			`(sort [1 "a"])`,
+			`(sort #(do "x") [1 2])`,
+			`(partition 0 [1])`,
+			`(vec (distinct [(make-slice "int" 1)]))`,
+			`(group-by)`,
+		} {
+			_, err := executeWithStdlib(t, src)
+			assert.Error(t, err, src)
+		}
+	})
+}
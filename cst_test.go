@@ -0,0 +1,100 @@
+package parens_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spy16/parens/lexer"
+	"github.com/spy16/parens/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCST_RoundTrip(suite *testing.T) {
+	suite.Parallel()
+
+	sources := map[string]string{
+		"Empty":       "",
+		"OnlyTrivia":  "  ; just a comment\n\n",
+		"Collections": "(defn add [a b] ; adds\n\t(+ a b))\n{:a 1\n :b [1 2 ]}\n#{1 2}",
+		"Prefixes":    "'(1 2) #_ (ignored)  '  sym #(+ % %2)",
+		"Literals":    "\"str\\n\" `raw\nstr` \\a \\newline #\"\\d+\" 0xFF 3/4 1e3 :kw",
+		"Tagged":      "#inst \"2018-11-03\" [#uuid \"f81d4fae-7dec-11d0-a765-00a0c91e6bf6\"]",
+		"UnreadTags":  "#unknown/tag [1] #inst \"not a time\"",
+		"CRLF":        "(a)\r\n(b)\r\n",
+		"Unicode":     "(println \"π\" π) ; ∑",
+	}
+
+	files, err := filepath.Glob("examples/*.lisp")
+	require.NoError(suite, err)
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		require.NoError(suite, err)
+		sources[file] = string(data)
+	}
+
+	for name, src := range sources {
+		src := src
+		suite.Run(name, func(t *testing.T) {
+			node, err := parser.ParseCST(name, src)
+			require.NoError(t, err)
+			assert.Equal(t, src, node.String())
+		})
+	}
+}
+
+func TestParseCST_Structure(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("Nodes", func(t *testing.T) {
+		node, err := parser.ParseCST("<test>", "; header\n(a [b] #_ c 'd #tag {:e #{f}} #(g %)) ; end\n")
+		require.NoError(t, err)
+
+		assert.Equal(t, parser.ModuleNode, node.Kind)
+		require.Len(t, node.Children, 1)
+		assert.Equal(t, " ; end\n", tokensString(node.Trailing))
+
+		list := node.Children[0]
+		assert.Equal(t, parser.ListNode, list.Kind)
+		assert.Equal(t, "; header\n", tokensString(list.Leading))
+		assert.Equal(t, ")", list.Close.Value)
+
+		kinds := []parser.NodeKind{}
+		for _, child := range list.Children {
+			kinds = append(kinds, child.Kind)
+		}
+		assert.Equal(t, []parser.NodeKind{
+			parser.AtomNode, parser.VectorNode, parser.DiscardNode,
+			parser.QuoteNode, parser.TaggedNode, parser.FnNode,
+		}, kinds)
+
+		tagged := list.Children[4]
+		require.Len(t, tagged.Children, 2)
+		assert.Equal(t, "tag", tagged.Children[0].Token.Value)
+		assert.Equal(t, parser.MapNode, tagged.Children[1].Kind)
+		assert.Equal(t, parser.SetNode, tagged.Children[1].Children[1].Kind)
+	})
+
+	suite.Run("Trailing", func(t *testing.T) {
+		node, err := parser.ParseCST("<test>", "(a ; comment\n  )")
+		require.NoError(t, err)
+
+		list := node.Children[0]
+		require.Len(t, list.Children, 1)
+		assert.Equal(t, " ; comment\n  ", tokensString(list.Trailing))
+	})
+
+	suite.Run("SyntaxError", func(t *testing.T) {
+		_, err := parser.ParseCST("<test>", "(a [b)")
+		assert.Error(t, err)
+	})
+}
+
+func tokensString(tokens []lexer.Token) string {
+	str := ""
+	for _, token := range tokens {
+		str += token.Value
+	}
+	return str
+}
@@ -0,0 +1,91 @@
+package parens_test
+
+import (
+	"math/big"
+	"regexp"
+	"testing"
+
+	"github.com/spy16/parens/lexer"
+	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadData(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("Values", func(t *testing.T) {
+		src := `nil true false 10 1.5 3/4 "str" \a :kw #"\d+"
+			[1 [2]] {:a 1 :b {:c "d"}} #{1 :x} #uuid "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"`
+
+		vals, err := parser.ReadData("<test>", src)
+		require.NoError(t, err)
+
+		uuid, err := parser.ParseUUID("f81d4fae-7dec-11d0-a765-00a0c91e6bf6")
+		require.NoError(t, err)
+
+		expected := []interface{}{
+			nil, true, false, int64(10), 1.5, big.NewRat(3, 4), "str", 'a', kw(":kw"),
+			regexp.MustCompile(`\d+`),
+			vecOf(int64(1), vecOf(int64(2))),
+			mapOf(kw(":a"), int64(1), kw(":b"), mapOf(kw(":c"), "d")),
+			setOf(int64(1), kw(":x")),
+			uuid,
+		}
+		assert.Equal(t, expected, vals)
+	})
+
+	suite.Run("CodeIsNotEvaluated", func(t *testing.T) {
+		vals, err := parser.ReadData("<test>", `[undefined-symbol (exit) '(a b) #(+ % 1)]`)
+		require.NoError(t, err)
+		require.Len(t, vals, 1)
+
+		items := vals[0].(*value.Vector).Slice()
+		require.Len(t, items, 4)
+		assert.Equal(t, value.ParseSymbol("undefined-symbol"), items[0])
+		assert.Equal(t, value.NewList(value.ParseSymbol("exit")), items[1])
+		assert.Equal(t, value.NewList(value.ParseSymbol("quote"), value.NewList(value.ParseSymbol("a"), value.ParseSymbol("b"))), items[2])
+		assert.IsType(t, parser.FnExpr{}, items[3])
+	})
+
+	suite.Run("UnknownTag", func(t *testing.T) {
+		vals, err := parser.ReadData("<test>", `#point [1 2]`)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			parser.TaggedLiteral{Tag: "point", Form: vecOf(int64(1), int64(2))},
+		}, vals)
+	})
+
+	suite.Run("Errors", func(t *testing.T) {
+		for _, src := range []string{`[1 2`, `#{1 1}`, `#inst "not a time"`} {
+			_, err := parser.ReadData("<test>", src)
+			assert.Error(t, err, src)
+		}
+	})
+
+	suite.Run("NoCustomMacros", func(t *testing.T) {
+		called := false
+		defaults := parser.DefaultMacros()
+		require.NoError(t, defaults.RegisterDispatchMacro('~', func(rd *parser.TokenReader, token lexer.Token) (parser.Expr, error) {
+			called = true
+			return rd.ReadForm()
+		}))
+		defer defaults.UnregisterDispatchMacro('~')
+
+		_, err := parser.ReadData("<test>", `#~x`)
+		assert.Error(t, err)
+		assert.False(t, called)
+
+		vals, err := parser.NewParser(true).ReadData("<test>", `#~x`)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{value.ParseSymbol("x")}, vals)
+		assert.True(t, called)
+	})
+
+	suite.Run("Empty", func(t *testing.T) {
+		vals, err := parser.ReadData("<test>", "; nothing here\n")
+		require.NoError(t, err)
+		assert.Empty(t, vals)
+	})
+}
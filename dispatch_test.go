@@ -0,0 +1,383 @@
+package parens_test
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spy16/parens"
+	"github.com/spy16/parens/lexer"
+	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/stdlib"
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_DispatchForms(suite *testing.T) {
+	suite.Parallel()
+
+	cases := map[string]interface{}{
+		`(#(+ % 1) 1)`:              int64(2),
+		`(#(- %2 %1) 1 5)`:          int64(4),
+		`(#(apply + %1 %&) 1 2 3)`:  int64(6),
+		`(#(do 10))`:                int64(10),
+		`(#(vector %) (get {} :a))`: vecOf(nil),
+		`#{1 "a"}`:                  setOf(int64(1), "a"),
+		`#{}`:                       setOf(),
+		`[1 #_2 3]`:                 vecOf(int64(1), int64(3)),
+		`(+ 1 #_(undefined-fn) 2)`:  int64(3),
+		`[#_ #_ 1 2 3]`:             vecOf(int64(3)),
+		`(do 1 #_2)`:                int64(1),
+		`{:a 1 #_:b #_2}`:           mapOf(kw(":a"), int64(1)),
+		`##Inf`:                     math.Inf(1),
+		`[##-Inf]`:                  vecOf(math.Inf(-1)),
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			res, err := executeWithStdlib(t, src)
+			require.NoError(t, err)
+			assert.Equal(t, expected, res)
+		})
+	}
+
+	suite.Run("Regex", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `#"\d+\.\d+"`)
+		require.NoError(t, err)
+		assert.Equal(t, regexp.MustCompile(`\d+\.\d+`), res)
+	})
+
+	suite.Run("NaN", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `##NaN`)
+		require.NoError(t, err)
+		assert.True(t, math.IsNaN(res.(float64)))
+	})
+
+	suite.Run("Errors", func(t *testing.T) {
+		for _, src := range []string{
+			`##Foo`,
+			`## Inf`,
+			`##1`,
+			`(#(+ % %2) 1)`,
+			`#(#(%))`,
+			`#{1 1}`,
+			`#{[1] [1]}`,
+			`#{(make-slice "int" 1)}`,
+			`#"[a-"`,
+			`(#(%) 1 2)`,
+		} {
+			_, err := executeWithStdlib(t, src)
+			assert.Error(t, err, src)
+		}
+
+		for _, src := range []string{`#(+ %0)`, `#(+ %-1)`, `#(+ %+1)`} {
+			_, err := parser.Parse("test", src)
+			assert.Error(t, err, src)
+		}
+	})
+}
+
+func executeWithStdlib(t *testing.T, src string) (interface{}, error) {
+	scope := parens.NewScope(nil)
+	require.NoError(t, stdlib.RegisterAll(scope))
+	return parens.New(scope).Execute(src)
+}
+
+var kw = value.ParseKeyword
+
+func mapOf(kvs ...interface{}) *value.Map {
+	m, err := value.NewMap(kvs...)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+var vecOf = value.NewVector
+
+func setOf(items ...interface{}) *value.Set {
+	set, err := value.NewSet(items...)
+	if err != nil {
+		panic(err)
+	}
+	return set
+}
+
+func TestExecute_ReaderMacros(suite *testing.T) {
+	suite.Parallel()
+
+	derefMacro := func(rd *parser.TokenReader, token lexer.Token) (parser.Expr, error) {
+		form, err := rd.ReadForm()
+		if err != nil {
+			return nil, err
+		}
+
+		return parser.ListExpr{
+			List: []parser.Expr{parser.SymbolExpr{Symbol: "deref"}, form},
+		}, nil
+	}
+
+	upperMacro := func(rd *parser.TokenReader, token lexer.Token) (parser.Expr, error) {
+		next := rd.NextToken()
+		if next == nil || next.Type != lexer.STRING {
+			return nil, errors.New("expecting string after '$'")
+		}
+
+		return mockExpr(strings.ToUpper(strings.Trim(next.Value, `"`)), nil), nil
+	}
+
+	ignoreMacro := func(rd *parser.TokenReader, token lexer.Token) (parser.Expr, error) {
+		_, err := rd.ReadForm()
+		return nil, err
+	}
+
+	p := parser.NewParser(false)
+	require.NoError(suite, p.Macros.RegisterReaderMacro('@', derefMacro))
+	require.NoError(suite, p.Macros.RegisterReaderMacro('$', upperMacro))
+	require.NoError(suite, p.Macros.RegisterDispatchMacro('!', ignoreMacro))
+
+	execute := func(scope parser.Scope, src string) (interface{}, error) {
+		interp := parens.New(scope)
+		interp.Parse = p.Parse
+		return interp.Execute(src)
+	}
+
+	stdScope := func(t *testing.T) parser.Scope {
+		scope := parens.NewScope(nil)
+		require.NoError(t, stdlib.RegisterAll(scope))
+		return scope
+	}
+
+	suite.Run("PrefixMacro", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		scope.Bind("counter", 10)
+		scope.Bind("deref", func(v int) int { return v * 2 })
+
+		res, err := execute(scope, `@counter`)
+		require.NoError(t, err)
+		assert.Equal(t, 20, res)
+	})
+
+	suite.Run("MacroReadingTokens", func(t *testing.T) {
+		res, err := execute(stdScope(t), `[$"hello" $ "world"]`)
+		require.NoError(t, err)
+		assert.Equal(t, vecOf("HELLO", "WORLD"), res)
+
+		_, err = execute(stdScope(t), `$10`)
+		assert.Error(t, err)
+	})
+
+	suite.Run("DispatchMacro", func(t *testing.T) {
+		res, err := execute(stdScope(t), `[1 #!(unknown) 2]`)
+		require.NoError(t, err)
+		assert.Equal(t, vecOf(int64(1), int64(2)), res)
+	})
+
+	suite.Run("MacroCharInsideSymbol", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		scope.Bind("a@b", "symbol")
+
+		res, err := execute(scope, `a@b`)
+		require.NoError(t, err)
+		assert.Equal(t, "symbol", res)
+	})
+
+	suite.Run("Isolation", func(t *testing.T) {
+		// without the macro, '@counter' is just a symbol.
+		isSymbol := func(p *parser.Parser) bool {
+			expr, err := p.Parse("test", `@counter`)
+			require.NoError(t, err)
+			_, ok := expr.(parser.ModuleExpr).Exprs[0].(parser.SymbolExpr)
+			return ok
+		}
+		assert.True(t, isSymbol(&parser.Parser{Macros: parser.DefaultMacros()}), "macros of a parser must not affect the defaults")
+		assert.False(t, isSymbol(p))
+
+		_, err := parser.NewParser(true).Parse("test", `#!x`)
+		assert.Error(t, err)
+
+		clone := p.Macros.Clone()
+		clone.UnregisterReaderMacro('@')
+		clone.UnregisterDispatchMacro('!')
+
+		other := &parser.Parser{Macros: clone}
+		assert.True(t, isSymbol(other))
+		_, err = other.Parse("test", `#!x`)
+		assert.Error(t, err)
+
+		_, err = p.Parse("test", `[@counter #!x]`)
+		assert.NoError(t, err)
+	})
+
+	suite.Run("InheritDefaults", func(t *testing.T) {
+		defaults := parser.DefaultMacros()
+		require.NoError(t, defaults.RegisterDispatchMacro('?', ignoreMacro))
+		defer defaults.UnregisterDispatchMacro('?')
+
+		_, err := parser.NewParser(true).Parse("test", `#?x`)
+		assert.NoError(t, err)
+
+		_, err = parser.NewParser(false).Parse("test", `#?x`)
+		assert.Error(t, err)
+
+		_, err = (&parser.Parser{}).Parse("test", `#?x`)
+		assert.Error(t, err)
+	})
+
+	suite.Run("ReservedCharacters", func(t *testing.T) {
+		for _, ch := range []rune{'(', '"', '#', '1', '-', ' ', ':', 'a', 'π', '*', '?', '%'} {
+			assert.Error(t, parser.RegisterReaderMacro(ch, derefMacro), string(ch))
+		}
+
+		p := parser.NewParser(true)
+		require.Error(t, p.Macros.RegisterReaderMacro('a', derefMacro))
+		res, err := p.ReadData("test", `and`)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{value.ParseSymbol("and")}, res)
+
+		for _, ch := range []rune{'(', '{', '_', '#', '"', 'a', ' '} {
+			assert.Error(t, parser.RegisterDispatchMacro(ch, derefMacro), string(ch))
+		}
+	})
+}
+
+func TestExecute_TaggedLiterals(suite *testing.T) {
+	suite.Parallel()
+
+	type point struct{ X, Y int64 }
+
+	require.NoError(suite, parser.RegisterTag("test/point", func(form interface{}) (interface{}, error) {
+		coords, ok := form.(*value.Vector)
+		if !ok || coords.Count() != 2 {
+			return nil, errors.New("expecting vector of 2 numbers")
+		}
+
+		x, _ := coords.Nth(0)
+		y, _ := coords.Nth(1)
+		return point{X: x.(int64), Y: y.(int64)}, nil
+	}))
+
+	require.NoError(suite, parser.RegisterTag("test/form", func(form interface{}) (interface{}, error) {
+		return form, nil
+	}))
+
+	suite.Run("Inst", func(t *testing.T) {
+		cases := map[string]time.Time{
+			`#inst "2018-11-03T10:20:30Z"`:      time.Date(2018, 11, 3, 10, 20, 30, 0, time.UTC),
+			`#inst "2018-11-03T10:20:30.5Z"`:    time.Date(2018, 11, 3, 10, 20, 30, 5e8, time.UTC),
+			`#inst "2018-11-03"`:                time.Date(2018, 11, 3, 0, 0, 0, 0, time.UTC),
+			`#inst "2018"`:                      time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			`#inst "2018-11-03T10:20:30+05:30"`: time.Date(2018, 11, 3, 4, 50, 30, 0, time.UTC),
+		}
+
+		for src, expected := range cases {
+			res, err := executeWithStdlib(t, src)
+			require.NoError(t, err, src)
+			require.IsType(t, time.Time{}, res)
+			assert.True(t, expected.Equal(res.(time.Time)), src)
+		}
+
+		_, err := executeWithStdlib(t, `#inst "yesterday"`)
+		assert.Error(t, err)
+
+		_, err = executeWithStdlib(t, `#inst 10`)
+		assert.Error(t, err)
+	})
+
+	suite.Run("UUID", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `#uuid "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"`)
+		require.NoError(t, err)
+		require.IsType(t, parser.UUID{}, res)
+		assert.Equal(t, "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", res.(parser.UUID).String())
+
+		_, err = executeWithStdlib(t, `#uuid "f81d4fae7dec11d0a76500a0c91e6bf6"`)
+		assert.Error(t, err)
+	})
+
+	suite.Run("RegisteredTag", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `[#test/point [1 2] #test/point [3 4]]`)
+		require.NoError(t, err)
+		assert.Equal(t, vecOf(point{1, 2}, point{3, 4}), res)
+
+		_, err = executeWithStdlib(t, `#test/point [1]`)
+		assert.Error(t, err)
+	})
+
+	suite.Run("UnknownTag", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `#test/unknown {:a 1}`)
+		require.NoError(t, err)
+		assert.Equal(t, parser.TaggedLiteral{
+			Tag:  "test/unknown",
+			Form: mapOf(kw(":a"), int64(1)),
+		}, res)
+	})
+
+	suite.Run("Isolation", func(t *testing.T) {
+		p1, p2 := parser.NewParser(false), parser.NewParser(false)
+		require.NoError(t, p1.Tags.Register("test/isolated", func(form interface{}) (interface{}, error) {
+			return "read", nil
+		}))
+
+		unread := parser.TaggedLiteral{Tag: "test/isolated", Form: int64(1)}
+		assert.Equal(t, "read", readTagged(t, p1, `#test/isolated 1`))
+		assert.Equal(t, unread, readTagged(t, p2, `#test/isolated 1`))
+
+		// tags registered in the DefaultTags are not inherited but the
+		// built-in tags are available.
+		assert.Equal(t, parser.TaggedLiteral{Tag: "test/point", Form: vecOf(int64(1), int64(2))},
+			readTagged(t, p2, `#test/point [1 2]`))
+		assert.IsType(t, time.Time{}, readTagged(t, p2, `#inst "2018"`))
+
+		p1.Tags.Unregister("test/isolated")
+		assert.Equal(t, unread, readTagged(t, p1, `#test/isolated 1`))
+	})
+
+	suite.Run("InheritDefaults", func(t *testing.T) {
+		p := parser.NewParser(true)
+		assert.IsType(t, point{}, readTagged(t, p, `#test/point [1 2]`))
+	})
+
+	suite.Run("ReadWhileParsing", func(t *testing.T) {
+		_, err := parser.Parse("test", `(do #inst "yesterday")`)
+		assert.EqualError(t, err, "test:1:5: invalid #inst literal: 'yesterday' is not a valid RFC3339 timestamp")
+
+		_, err = parser.Parse("test", "\n#uuid 10")
+		var errs parser.ErrorList
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, 2, errs[0].Pos.Line)
+	})
+
+	suite.Run("FormIsData", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `[#test/form undefined-symbol #test/form (undefined-fn 1)]`)
+		require.NoError(t, err)
+		assert.Equal(t, vecOf(
+			value.ParseSymbol("undefined-symbol"),
+			value.NewList(value.ParseSymbol("undefined-fn"), int64(1)),
+		), res)
+	})
+
+	suite.Run("MissingForm", func(t *testing.T) {
+		_, err := executeWithStdlib(t, `#inst`)
+		assert.Error(t, err)
+
+		_, err = executeWithStdlib(t, `#10 20`)
+		assert.Error(t, err)
+	})
+
+	suite.Run("InvalidTag", func(t *testing.T) {
+		assert.Error(t, parser.RegisterTag("", nil))
+		assert.Error(t, parser.RegisterTag("1abc", nil))
+	})
+}
+
+func readTagged(t *testing.T, p *parser.Parser, src string) interface{} {
+	vals, err := p.ReadData("test", src)
+	require.NoError(t, err)
+	require.Len(t, vals, 1)
+	return vals[0]
+}
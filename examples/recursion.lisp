@@ -6,4 +6,4 @@
 
 
 ; finally call the factorial function
-(printf "10! = %f\n" (factorial 10))
+(printf "10! = %d\n" (factorial 10))
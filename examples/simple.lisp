@@ -16,7 +16,7 @@
 ; let starts a new scope
 (let
   (label π 3)
-  (printf "integer part of pi is %f\n" π))
+  (printf "integer part of pi is %d\n" π))
 
 ; value of π should now be reset to original
 (printf "but real value of pi is %f\n" π)
@@ -36,10 +36,10 @@
 (label square (lambda [a] (* a a)))
 
 ; calling a lambda, obviously
-(printf "square of 2 is = %f\n" (square 2))
+(printf "square of 2 is = %d\n" (square 2))
 
 ; we need to do some math obviously
-(printf "complex math answer %f\n" (* 1 (- 2 (+ 1 (/ 3 3)))))
+(printf "complex math answer %d\n" (* 1 (- 2 (+ 1 (/ 3 3)))))
 
 ; time for real stuff.. fibonacci!
 (defn fib [n]
@@ -48,6 +48,6 @@
         (true (+ (fib (- n 1)) (fib (- n 2))))))
 
 ; what is the 10th number in the fibonacci sequence
-(printf "10th number in the fibonacci sequence = %f\n" (fib 10))
+(printf "10th number in the fibonacci sequence = %d\n" (fib 10))
 
 
@@ -0,0 +1,235 @@
+// Package format implements canonical formatting of parens source.
+//
+// Formatting keeps the line breaks chosen by the author but normalizes
+// everything else:
+//
+//   - Indentation is recomputed. Arguments of body forms (e.g. defn, let,
+//     cond, ->) are indented by 2 spaces. Arguments of other lists are
+//     aligned with the first argument if it is on the same line as the
+//     head, otherwise with the head. Items of vectors, maps and sets are
+//     aligned with the first item.
+//   - Forms on the same line are separated by a single space. There is no
+//     space after opening and before closing brackets.
+//   - Closing brackets are moved to the line of the last item unless a
+//     comment precedes them.
+//   - Top-level forms are placed on separate lines. At most one blank line
+//     is retained between forms.
+//   - Comments are retained as is. Trailing whitespaces are removed and the
+//     output ends with a single newline.
+//
+// Formatting is idempotent, i.e., formatting already formatted source does
+// not change it.
+package format
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/spy16/parens/lexer"
+	"github.com/spy16/parens/parser"
+)
+
+// Source formats the src and returns the formatted source. Returns error if
+// the src has syntax errors.
+func Source(name string, src []byte) ([]byte, error) {
+	root, err := parser.ParseCST(name, string(src))
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &printer{}
+	pr.module(root)
+	return []byte(pr.sb.String()), nil
+}
+
+// RegisterBodyForm registers the name of a macro or special form whose
+// arguments should be indented as a body (i.e., by 2 spaces) instead of
+// being aligned.
+func RegisterBodyForm(name string) {
+	bodyFormsMu.Lock()
+	defer bodyFormsMu.Unlock()
+	bodyForms[name] = true
+}
+
+var (
+	bodyFormsMu sync.RWMutex
+	bodyForms   = map[string]bool{
+		"defn":     true,
+		"lambda":   true,
+		"let":      true,
+		"do":       true,
+		"cond":     true,
+		"->":       true,
+		"->>":      true,
+		"lazy-seq": true,
+	}
+)
+
+func isBodyForm(node *parser.Node) bool {
+	if node.Kind != parser.AtomNode || node.Token.Type != lexer.SYMBOL {
+		return false
+	}
+
+	bodyFormsMu.RLock()
+	defer bodyFormsMu.RUnlock()
+	return bodyForms[node.Token.Value]
+}
+
+// printer writes the formatted nodes while tracking the current column.
+type printer struct {
+	sb     strings.Builder
+	column int
+
+	// lastComment is true if the last thing written is a comment which
+	// must be followed by a newline.
+	lastComment bool
+}
+
+func (pr *printer) module(root *parser.Node) {
+	for _, child := range root.Children {
+		newlines := pr.trivia(child.Leading, 0)
+		if pr.sb.Len() > 0 {
+			pr.newline(newlines > 1, 0)
+		}
+		pr.node(child)
+	}
+
+	pr.trivia(root.Trailing, 0)
+	if pr.sb.Len() > 0 {
+		pr.sb.WriteString("\n")
+	}
+}
+
+func (pr *printer) node(node *parser.Node) {
+	start := pr.column
+	pr.write(node.Token.Value)
+
+	switch node.Kind {
+	case parser.ListNode, parser.FnNode, parser.VectorNode, parser.MapNode, parser.SetNode:
+		pr.collection(node, start)
+
+	case parser.TaggedNode:
+		pr.write(node.Children[0].Token.Value)
+		pr.item(node.Children[1], start, true)
+
+	case parser.QuoteNode, parser.DiscardNode, parser.MacroNode:
+		pr.item(node.Children[0], start, false)
+	}
+}
+
+func (pr *printer) collection(node *parser.Node, start int) {
+	indent := start + utf8.RuneCountInString(node.Token.Value)
+	body := false
+	if len(node.Children) > 0 && (node.Kind == parser.ListNode || node.Kind == parser.FnNode) {
+		body = isBodyForm(node.Children[0])
+	}
+
+	for i, child := range node.Children {
+		newlines := pr.trivia(child.Leading, indent)
+		if newlines > 0 || pr.lastComment {
+			pr.newline(newlines > 1, indent)
+		} else if i > 0 {
+			pr.write(" ")
+		}
+
+		if i == 0 && (node.Kind == parser.ListNode || node.Kind == parser.FnNode) {
+			// rest of the items are aligned with the head unless the
+			// first argument follows the head on the same line or the
+			// list is a body form.
+			pr.node(child)
+			if body {
+				indent = start + 2
+			} else if len(node.Children) > 1 && !hasNewline(node.Children[1].Leading) {
+				indent = pr.column + 1
+			}
+			continue
+		}
+
+		pr.node(child)
+	}
+
+	pr.trivia(node.Trailing, indent)
+	if pr.lastComment {
+		pr.newline(false, indent)
+	}
+	pr.write(node.Close.Value)
+}
+
+// item writes the form following a prefix (e.g. quote). Form follows the
+// prefix on the same line unless there are comments between them.
+func (pr *printer) item(node *parser.Node, indent int, space bool) {
+	pr.trivia(node.Leading, indent)
+	if pr.lastComment {
+		pr.newline(false, indent)
+	} else if space {
+		pr.write(" ")
+	}
+	pr.node(node)
+}
+
+// trivia writes the comments in the trivia and returns the number of
+// newlines before the next form. Comment on the same line as the previous
+// form stays on that line.
+func (pr *printer) trivia(tokens []lexer.Token, indent int) int {
+	newlines := 0
+	for _, token := range tokens {
+		switch token.Type {
+		case lexer.NEWLINE:
+			if token.Value == "\n" {
+				newlines++
+			}
+
+		case lexer.COMMENT:
+			if newlines > 0 || pr.lastComment {
+				if pr.sb.Len() > 0 {
+					pr.newline(newlines > 1, indent)
+				}
+			} else if pr.sb.Len() > 0 {
+				pr.write(" ")
+			}
+
+			// comment token includes the line-break ending it.
+			pr.write(strings.TrimRight(token.Value, " \t\r\n"))
+			pr.lastComment = true
+			newlines = 0
+			if strings.HasSuffix(token.Value, "\n") {
+				newlines = 1
+			}
+		}
+	}
+
+	return newlines
+}
+
+// newline starts a new line (preceded by a blank line if blank is true)
+// and indents it.
+func (pr *printer) newline(blank bool, indent int) {
+	if blank {
+		pr.sb.WriteString("\n")
+	}
+	pr.sb.WriteString("\n")
+	pr.sb.WriteString(strings.Repeat(" ", indent))
+	pr.column = indent
+	pr.lastComment = false
+}
+
+func (pr *printer) write(str string) {
+	pr.sb.WriteString(str)
+	pr.lastComment = false
+
+	if nl := strings.LastIndexByte(str, '\n'); nl >= 0 {
+		pr.column = utf8.RuneCountInString(str[nl+1:])
+	} else {
+		pr.column += utf8.RuneCountInString(str)
+	}
+}
+
+func hasNewline(tokens []lexer.Token) bool {
+	for _, token := range tokens {
+		if token.Type == lexer.NEWLINE || token.Type == lexer.COMMENT {
+			return true
+		}
+	}
+	return false
+}
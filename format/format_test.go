@@ -0,0 +1,108 @@
+package format_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/spy16/parens/format"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSource(suite *testing.T) {
+	suite.Parallel()
+
+	cases := []struct {
+		title string
+		src   string
+		want  string
+	}{
+		{
+			title: "BodyForms",
+			src:   "(defn f [x]\n(cond\n((== x 1) x)\n     (true 2)))",
+			want:  "(defn f [x]\n  (cond\n    ((== x 1) x)\n    (true 2)))\n",
+		},
+		{
+			title: "ThreadingForms",
+			src:   "(-> (+ 1 2)\n(square)\n   (println))",
+			want:  "(-> (+ 1 2)\n  (square)\n  (println))\n",
+		},
+		{
+			title: "AlignArguments",
+			src:   "(println \"a\"\n\"b\")\n(println\n\"a\" \"b\")",
+			want:  "(println \"a\"\n         \"b\")\n(println\n \"a\" \"b\")\n",
+		},
+		{
+			title: "AlignItems",
+			src:   "{:a 1\n:b [1\n2]}",
+			want:  "{:a 1\n :b [1\n     2]}\n",
+		},
+		{
+			title: "Spacing",
+			src:   "(  +   1\t2 )   'x  #_  y #inst   \"2018\"",
+			want:  "(+ 1 2)\n'x\n#_y\n#inst \"2018\"\n",
+		},
+		{
+			title: "BlankLines",
+			src:   "\n\n(a)\n\n\n\n(b)\n\n",
+			want:  "(a)\n\n(b)\n",
+		},
+		{
+			title: "Comments",
+			src:   ";; header   \n\n(defn f [] ; trailing\n; inside\n(g)\n; last\n)\n; end",
+			want:  ";; header\n\n(defn f [] ; trailing\n  ; inside\n  (g)\n  ; last\n  )\n; end\n",
+		},
+		{
+			title: "Empty",
+			src:   "",
+			want:  "",
+		},
+	}
+
+	for _, cs := range cases {
+		cs := cs
+		suite.Run(cs.title, func(t *testing.T) {
+			got, err := format.Source("<test>", []byte(cs.src))
+			require.NoError(t, err)
+			assert.Equal(t, cs.want, string(got))
+
+			again, err := format.Source("<test>", got)
+			require.NoError(t, err)
+			assert.Equal(t, string(got), string(again), "formatting is not idempotent")
+		})
+	}
+
+	suite.Run("SyntaxError", func(t *testing.T) {
+		_, err := format.Source("<test>", []byte("(a"))
+		assert.EqualError(t, err, "<test>:1:1: unclosed list")
+	})
+}
+
+func TestSource_Examples(t *testing.T) {
+	t.Parallel()
+
+	files, err := filepath.Glob("../examples/*.lisp")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		src, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+
+		once, err := format.Source(file, src)
+		require.NoError(t, err, file)
+
+		twice, err := format.Source(file, once)
+		require.NoError(t, err, file)
+		assert.Equal(t, string(once), string(twice), file)
+	}
+}
+
+func TestRegisterBodyForm(t *testing.T) {
+	format.RegisterBodyForm("when-test")
+
+	got, err := format.Source("<test>", []byte("(when-test x\n(y))"))
+	require.NoError(t, err)
+	assert.Equal(t, "(when-test x\n  (y))\n", string(got))
+}
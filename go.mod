@@ -1,5 +1,7 @@
 module github.com/spy16/parens
 
+go 1.24
+
 require (
 	github.com/chzyer/logex v1.1.10 // indirect
 	github.com/chzyer/readline v0.0.0-20180603132655-2972be24d48e
@@ -0,0 +1,85 @@
+package parens_test
+
+import (
+	"testing"
+
+	"github.com/spy16/parens"
+	"github.com/spy16/parens/stdlib"
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_Keywords(suite *testing.T) {
+	suite.Parallel()
+
+	cases := map[string]interface{}{
+		`:name`:                            kw(":name"),
+		`:user/id`:                         value.NewKeyword("user", "id"),
+		`(== :a :a)`:                       true,
+		`(== :a ":a")`:                     false,
+		`(:name {:name "parens" :v 1})`:    "parens",
+		`(:user/id {:user/id 10 :id 20})`:  int64(10),
+		`(:missing {:name "parens"})`:      nil,
+		`(:missing {:name "parens"} "na")`: "na",
+		`(:name nil)`:                      nil,
+		`(:name [1 2])`:                    nil,
+		`(#(:x %) {:x 1})`:                 int64(1),
+		`(keyword "a")`:                    kw(":a"),
+		`(keyword ":a")`:                   kw(":a"),
+		`(keyword "user" "id")`:            kw(":user/id"),
+		`(keyword 'sym)`:                   kw(":sym"),
+		`(symbol "str/join")`:              value.ParseSymbol("str/join"),
+		`(symbol :a/b)`:                    value.ParseSymbol("a/b"),
+		`(name :user/id)`:                  "id",
+		`(name 'str/join)`:                 "join",
+		`(name "plain")`:                   "plain",
+		`(namespace :user/id)`:             "user",
+		`(namespace :id)`:                  nil,
+		`(keyword? :a)`:                    true,
+		`(keyword? ":a")`:                  false,
+		`(symbol? 'a)`:                     true,
+		`(symbol? :a)`:                     false,
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			res, err := executeWithStdlib(t, src)
+			require.NoError(t, err)
+			assert.Equal(t, expected, res)
+		})
+	}
+
+	suite.Run("GoMapLookup", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		require.NoError(t, stdlib.RegisterAll(scope))
+		scope.Bind("config", map[value.Keyword]int{kw(":port"): 8080})
+
+		res, err := parens.New(scope).Execute(`(:port config)`)
+		require.NoError(t, err)
+		assert.Equal(t, 8080, res)
+	})
+
+	suite.Run("KeywordAsGoString", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		scope.Bind("str", func(s string) string { return s })
+
+		res, err := parens.New(scope).Execute(`(str :user/id)`)
+		require.NoError(t, err)
+		assert.Equal(t, ":user/id", res)
+	})
+
+	suite.Run("Errors", func(t *testing.T) {
+		for _, src := range []string{`(:a)`, `(:a {} 1 2)`, `(keyword 1)`, `(name 1)`, `(namespace "a")`} {
+			_, err := executeWithStdlib(t, src)
+			assert.Error(t, err, src)
+		}
+	})
+}
+
+func TestExecute_QuotedSymbols(t *testing.T) {
+	res, err := executeWithStdlib(t, `'user/id`)
+	require.NoError(t, err)
+	assert.Equal(t, value.NewSymbol("user", "id"), res)
+}
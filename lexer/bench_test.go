@@ -0,0 +1,102 @@
+package lexer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spy16/parens/lexer"
+	"github.com/spy16/parens/parser"
+)
+
+// sampleForms is a representative piece of source which is repeated to
+// build a large source for the benchmarks.
+const sampleForms = `
+; compute the factorial of n
+(defn factorial [n]
+	(cond
+		[(<= n 1) 1]
+		[true (* n (factorial (- n 1)))]))
+
+(label config {:name "parens" :version 0.1 :tags ["lisp" "go"] :limit 0xFF})
+(println "factorial of 10 is" (factorial 10) \newline)
+(map #(+ % 1.5e3) [1 2 3 4_000 3/4 10N])
+#_ (ignored form)
+(if (matches #"\d+" "1234") 'yes 'no)
+`
+
+func largeSource(size int) string {
+	var sb strings.Builder
+	for sb.Len() < size {
+		sb.WriteString(sampleForms)
+	}
+	return sb.String()
+}
+
+func BenchmarkLexer_Tokens(b *testing.B) {
+	src := largeSource(1 << 20)
+
+	b.SetBytes(int64(len(src)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := lexer.New(src).Tokens(); err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
+
+func BenchmarkLexer_Next(b *testing.B) {
+	src := largeSource(1 << 20)
+
+	b.SetBytes(int64(len(src)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lex := lexer.New(src)
+		for {
+			if _, err := lex.Next(); err != nil {
+				if err == lexer.ErrEOF {
+					break
+				}
+				b.Fatalf("unexpected error: %s", err)
+			}
+		}
+	}
+}
+
+// BenchmarkLexer_NextAppend collects the tokens using Next for comparison
+// with BenchmarkLexer_Tokens.
+func BenchmarkLexer_NextAppend(b *testing.B) {
+	src := largeSource(1 << 20)
+
+	b.SetBytes(int64(len(src)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var tokens []lexer.Token
+		lex := lexer.New(src)
+		for {
+			token, err := lex.Next()
+			if err != nil {
+				if err == lexer.ErrEOF {
+					break
+				}
+				b.Fatalf("unexpected error: %s", err)
+			}
+			tokens = append(tokens, *token)
+		}
+	}
+}
+
+func BenchmarkParser_Parse(b *testing.B) {
+	src := largeSource(1 << 20)
+
+	b.SetBytes(int64(len(src)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parser.Parse("<bench>", src); err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+	}
+}
@@ -18,3 +18,13 @@ type ErrUnrecognizedToken struct {
 func (err ErrUnrecognizedToken) Error() string {
 	return fmt.Sprintf("unrecognized token '%s'", err.val)
 }
+
+// ErrInvalidEscape is returned when a string literal contains an invalid
+// or incomplete escape sequence.
+type ErrInvalidEscape struct {
+	seq string
+}
+
+func (err ErrInvalidEscape) Error() string {
+	return fmt.Sprintf("invalid escape sequence '%s' in string literal", err.seq)
+}
@@ -21,14 +21,25 @@ func New(src string) *Lexer {
 
 // Lexer performs lexical analysis of LISP.
 type Lexer struct {
-	cur utfstrings.Cursor
+	// MacroChars are the characters that result in a MACRO token when
+	// found at the beginning of a token. Characters with special meaning
+	// (e.g. '(', '"', '#') always retain their meaning.
+	MacroChars map[rune]bool
+
+	// SkipTrivia makes the lexer drop WHITESPACE, NEWLINE and COMMENT
+	// tokens.
+	SkipTrivia bool
+
+	cur       utfstrings.Cursor
+	lastStart int
 }
 
 // Tokens runs through the entire source and returns tokens.
 func (lex *Lexer) Tokens() ([]Token, error) {
-	tokens := []Token{}
+	tokens := make([]Token, 0, lex.EstimateTokens())
+
 	for {
-		token, err := lex.Next()
+		token, err := lex.next()
 		if err != nil {
 			if err == ErrEOF {
 				return tokens, nil
@@ -36,32 +47,65 @@ func (lex *Lexer) Tokens() ([]Token, error) {
 			return nil, err
 		}
 
-		tokens = append(tokens, *token)
+		tokens = append(tokens, token)
+	}
+}
+
+// EstimateTokens returns the approximate number of tokens remaining in the
+// source. It is meant to be used as the capacity when collecting tokens so
+// that the slice does not have to grow for typical sources.
+func (lex *Lexer) EstimateTokens() int {
+	remaining := len(lex.cur.String) - lex.cur.Start
+	if lex.SkipTrivia {
+		// most tokens are separated by a whitespace or newline token.
+		return remaining/3 + 1
 	}
+	return remaining/2 + 1
 }
 
 // Next consumes characters from source until the next token is found
 // and returns the token. If no token is identified till the end of
-// source, ErrEOF will be returned.
+// source, ErrEOF will be returned. If the characters do not form a valid
+// token, they are skipped and an error is returned. Calling Next again
+// continues lexing after the invalid characters.
 func (lex *Lexer) Next() (*Token, error) {
-	tokenType, err := lex.nextTokenType()
+	token, err := lex.next()
 	if err != nil {
 		return nil, err
 	}
 
-	token := Token{}
-	token.Start = lex.cur.Start
-	token.Value = lex.cur.String[lex.cur.Start:lex.cur.Pos]
-	token.Type = tokenType
-
-	lex.cur.Start = lex.cur.Pos
 	return &token, nil
 }
 
+func (lex *Lexer) next() (Token, error) {
+	for {
+		lex.lastStart = lex.cur.Start
+
+		tokenType, err := lex.nextTokenType()
+		if err != nil {
+			if err != ErrEOF {
+				lex.skipInvalid()
+			}
+			return Token{}, err
+		}
+
+		token := Token{
+			Type:  tokenType,
+			Start: lex.cur.Start,
+			Value: lex.cur.String[lex.cur.Start:lex.cur.Pos],
+		}
+		lex.cur.Start = lex.cur.Pos
+
+		if !lex.SkipTrivia || !token.IsTrivia() {
+			return token, nil
+		}
+	}
+}
+
 func (lex *Lexer) nextTokenType() (TokenType, error) {
 	switch ru := lex.cur.Next(); {
 	case ru == utfstrings.EOS:
-		return "", ErrEOF
+		return 0, ErrEOF
 
 	case ru == '(':
 		return LPAREN, nil
@@ -93,10 +137,28 @@ func (lex *Lexer) nextTokenType() (TokenType, error) {
 	case ru == '"':
 		lex.cur.Backup()
 		if err := scanString(&lex.cur); err != nil {
-			return "", err
+			return 0, err
 		}
 		return STRING, nil
 
+	case ru == '`':
+		lex.cur.Backup()
+		if err := scanRawString(&lex.cur); err != nil {
+			return 0, err
+		}
+		return STRING, nil
+
+	case ru == '\\':
+		lex.cur.Backup()
+		if err := scanCharacter(&lex.cur); err != nil {
+			return 0, err
+		}
+		return CHARACTER, nil
+
+	case ru == '#':
+		lex.cur.Backup()
+		return scanDispatch(&lex.cur)
+
 	case ru == ';':
 		lex.cur.Backup()
 		scanComment(&lex.cur)
@@ -106,6 +168,9 @@ func (lex *Lexer) nextTokenType() (TokenType, error) {
 		scanKeyWord(&lex.cur)
 		return KEYWORD, nil
 
+	case lex.MacroChars[ru]:
+		return MACRO, nil
+
 	default:
 		lex.cur.Backup()
 		oldSel := lex.cur.Selection
@@ -119,6 +184,30 @@ func (lex *Lexer) nextTokenType() (TokenType, error) {
 		}
 		lex.cur.Selection = oldSel
 
-		return "", scanInvalidToken(&lex.cur)
+		return 0, scanInvalidToken(&lex.cur)
 	}
 }
+
+// Offset returns the offset in the source at which the last token returned
+// by Next or the last invalid token starts.
+func (lex *Lexer) Offset() int {
+	return lex.lastStart
+}
+
+// End returns the offset in the source at which the last token returned by
+// Next or the last invalid token ends.
+func (lex *Lexer) End() int {
+	return lex.cur.Start
+}
+
+// skipInvalid moves the cursor past the invalid token that was being
+// scanned.
+func (lex *Lexer) skipInvalid() {
+	if lex.cur.Pos == lex.cur.Start {
+		scanTillSeparator(&lex.cur)
+		if lex.cur.Pos == lex.cur.Start {
+			lex.cur.Next()
+		}
+	}
+	lex.cur.Start = lex.cur.Pos
+}
@@ -165,6 +165,28 @@ world"`
 		checkValidTokens(t, src, result{lexer.STRING, "\"hello\nworld\""})
 	})
 
+	suite.Run("EscapeSequences", func(t *testing.T) {
+		checkValidTokens(t, `"a\\b"`, result{lexer.STRING, `"a\\b"`})
+		checkValidTokens(t, `"\t\r\n\a\b\f\v"`, result{lexer.STRING, `"\t\r\n\a\b\f\v"`})
+		checkValidTokens(t, `"\x41\101\u00e9\U0001F600"`, result{lexer.STRING, `"\x41\101\u00e9\U0001F600"`})
+		checkValidTokens(t, `"\\"`, result{lexer.STRING, `"\\"`})
+	})
+
+	suite.Run("InvalidEscapeSequences", func(t *testing.T) {
+		for _, src := range []string{`"\q"`, `"\x4"`, `"\u00"`, `"\'"`} {
+			tokens, err := lexer.New(src).Tokens()
+			assert.IsType(t, &lexer.ErrInvalidEscape{}, err, src)
+			assert.Nil(t, tokens)
+		}
+	})
+
+	suite.Run("RawString", func(t *testing.T) {
+		checkValidTokens(t, "`hello`", result{lexer.STRING, "`hello`"})
+		checkValidTokens(t, "`\\d+\n\"`", result{lexer.STRING, "`\\d+\n\"`"})
+		checkValidTokens(t, "`line1\nline2`", result{lexer.STRING, "`line1\nline2`"})
+		checkInvalidTokens(t, "`hello", lexer.ErrUnterminatedString)
+	})
+
 	suite.Run("StringInList", func(t *testing.T) {
 		src := `("hello")`
 		checkValidTokens(t, src,
@@ -217,11 +239,158 @@ func TestLexer_Numbers(suite *testing.T) {
 
 	})
 
+	suite.Run("Radix", func(t *testing.T) {
+		checkValidTokens(t, "0xFF", result{lexer.NUMBER, "0xFF"})
+		checkValidTokens(t, "-0o17", result{lexer.NUMBER, "-0o17"})
+		checkValidTokens(t, "0b1010", result{lexer.NUMBER, "0b1010"})
+		checkValidTokens(t, "0xdead_beef", result{lexer.NUMBER, "0xdead_beef"})
+	})
+
+	suite.Run("Exponent", func(t *testing.T) {
+		checkValidTokens(t, "1e9", result{lexer.NUMBER, "1e9"})
+		checkValidTokens(t, "-1.5E-3", result{lexer.NUMBER, "-1.5E-3"})
+	})
+
+	suite.Run("Underscores", func(t *testing.T) {
+		checkValidTokens(t, "1_000_000", result{lexer.NUMBER, "1_000_000"})
+		checkValidTokens(t, "1_000.000_1", result{lexer.NUMBER, "1_000.000_1"})
+	})
+
+	suite.Run("RatioAndBigInt", func(t *testing.T) {
+		checkValidTokens(t, "3/4", result{lexer.NUMBER, "3/4"})
+		checkValidTokens(t, "-12345678901234567890N", result{lexer.NUMBER, "-12345678901234567890N"})
+	})
+
 	suite.Run("InvalidNumbers", func(t *testing.T) {
 		checkInvalidTokens(t, "1.09.9", nil)
+		checkInvalidTokens(t, "0xZZ", nil)
+		checkInvalidTokens(t, "1__000", nil)
+		checkInvalidTokens(t, "1_", nil)
+		checkInvalidTokens(t, "3/", nil)
+		checkInvalidTokens(t, "1e", nil)
+		checkInvalidTokens(t, "0b102", nil)
+		checkInvalidTokens(t, "1.", nil)
+		checkInvalidTokens(t, "0x", nil)
+		checkInvalidTokens(t, "1N0", nil)
+		checkInvalidTokens(t, "1/2N", nil)
+		checkInvalidTokens(t, "1e+", nil)
 	})
 }
 
+func TestLexer_Characters(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("SingleCharacter", func(t *testing.T) {
+		checkValidTokens(t, `\a`, result{lexer.CHARACTER, `\a`})
+		checkValidTokens(t, `\π`, result{lexer.CHARACTER, `\π`})
+		checkValidTokens(t, `\\`, result{lexer.CHARACTER, `\\`})
+		checkValidTokens(t, `\(`, result{lexer.CHARACTER, `\(`})
+	})
+
+	suite.Run("NamedAndCodePoints", func(t *testing.T) {
+		checkValidTokens(t, `\newline`, result{lexer.CHARACTER, `\newline`})
+		checkValidTokens(t, `\space`, result{lexer.CHARACTER, `\space`})
+		checkValidTokens(t, `\u03C0`, result{lexer.CHARACTER, `\u03C0`})
+		checkValidTokens(t, `\o101`, result{lexer.CHARACTER, `\o101`})
+	})
+
+	suite.Run("CharactersInList", func(t *testing.T) {
+		checkValidTokens(t, `(\a \))`,
+			result{lexer.LPAREN, "("},
+			result{lexer.CHARACTER, `\a`},
+			result{lexer.WHITESPACE, " "},
+			result{lexer.CHARACTER, `\)`},
+			result{lexer.RPAREN, ")"},
+		)
+	})
+
+	suite.Run("InvalidCharacters", func(t *testing.T) {
+		checkInvalidTokens(t, `\abc`, nil)
+		checkInvalidTokens(t, `\`, nil)
+		checkInvalidTokens(t, `\ `, nil)
+		checkInvalidTokens(t, `\u12`, nil)
+		checkInvalidTokens(t, `\o400`, nil)
+	})
+}
+
+func TestLexer_Dispatch(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("AnonymousFunction", func(t *testing.T) {
+		checkValidTokens(t, "#(inc %)",
+			result{lexer.DISPATCH, "#("},
+			result{lexer.SYMBOL, "inc"},
+			result{lexer.WHITESPACE, " "},
+			result{lexer.SYMBOL, "%"},
+			result{lexer.RPAREN, ")"},
+		)
+	})
+
+	suite.Run("Set", func(t *testing.T) {
+		checkValidTokens(t, "#{1}",
+			result{lexer.DISPATCH, "#{"},
+			result{lexer.NUMBER, "1"},
+			result{lexer.RDICT, "}"},
+		)
+	})
+
+	suite.Run("Discard", func(t *testing.T) {
+		checkValidTokens(t, "#_a",
+			result{lexer.DISPATCH, "#_"},
+			result{lexer.SYMBOL, "a"},
+		)
+	})
+
+	suite.Run("Regex", func(t *testing.T) {
+		checkValidTokens(t, `#"\d+"`, result{lexer.REGEX, `#"\d+"`})
+		checkValidTokens(t, `#"a\"b"`, result{lexer.REGEX, `#"a\"b"`})
+		checkInvalidTokens(t, `#"abc`, lexer.ErrUnterminatedString)
+	})
+
+	suite.Run("CustomDispatch", func(t *testing.T) {
+		checkValidTokens(t, "#!a",
+			result{lexer.DISPATCH, "#!"},
+			result{lexer.SYMBOL, "a"},
+		)
+	})
+
+	suite.Run("TaggedLiteral", func(t *testing.T) {
+		checkValidTokens(t, `#inst "2018"`,
+			result{lexer.DISPATCH, "#"},
+			result{lexer.SYMBOL, "inst"},
+			result{lexer.WHITESPACE, " "},
+			result{lexer.STRING, `"2018"`},
+		)
+	})
+
+	suite.Run("InvalidDispatch", func(t *testing.T) {
+		checkInvalidTokens(t, "#", nil)
+		checkInvalidTokens(t, "# a", nil)
+	})
+}
+
+func TestLexer_MacroChars(t *testing.T) {
+	lex := lexer.New("(@a b@c)")
+	lex.MacroChars = map[rune]bool{'@': true}
+
+	tokens, err := lex.Tokens()
+	require.NoError(t, err)
+
+	expected := []result{
+		{lexer.LPAREN, "("},
+		{lexer.MACRO, "@"},
+		{lexer.SYMBOL, "a"},
+		{lexer.WHITESPACE, " "},
+		{lexer.SYMBOL, "b@c"},
+		{lexer.RPAREN, ")"},
+	}
+	require.Equal(t, len(expected), len(tokens))
+	for i, res := range expected {
+		assert.Equal(t, res.typ, tokens[i].Type)
+		assert.Equal(t, res.val, tokens[i].Value)
+	}
+}
+
 func TestLexer_Symbols(suite *testing.T) {
 	suite.Parallel()
 
@@ -237,6 +406,11 @@ func TestLexer_Symbols(suite *testing.T) {
 		checkValidTokens(t, "≠", result{lexer.SYMBOL, "≠"})
 		checkValidTokens(t, "∂", result{lexer.SYMBOL, "∂"})
 	})
+
+	suite.Run("InvalidUTF8", func(t *testing.T) {
+		checkInvalidTokens(t, "hello\xff", nil)
+		checkInvalidTokens(t, "\xe2\x88", nil)
+	})
 }
 
 func TestLexer_Comment(suite *testing.T) {
@@ -278,6 +452,49 @@ func checkValidTokens(t *testing.T, src string, results ...result) {
 	}
 }
 
+func TestLexer_NextAfterError(t *testing.T) {
+	lex := lexer.New("(a 1..2 \"\\q\" b)")
+
+	types := []lexer.TokenType{}
+	errs := [][2]int{}
+	for {
+		token, err := lex.Next()
+		if err == lexer.ErrEOF {
+			break
+		} else if err != nil {
+			errs = append(errs, [2]int{lex.Offset(), lex.End()})
+			continue
+		}
+		types = append(types, token.Type)
+	}
+
+	assert.Equal(t, [][2]int{{3, 7}, {8, 12}}, errs)
+	assert.Equal(t, []lexer.TokenType{
+		lexer.LPAREN, lexer.SYMBOL, lexer.WHITESPACE, lexer.WHITESPACE,
+		lexer.WHITESPACE, lexer.SYMBOL, lexer.RPAREN,
+	}, types)
+}
+
+func TestLexer_Allocations(t *testing.T) {
+	src := `(defn greet [name] (println "hello" name :done 1.5e3 \newline)) ; comment`
+
+	allocs := testing.AllocsPerRun(100, func() {
+		lex := lexer.New(src)
+		for {
+			if _, err := lex.Next(); err != nil {
+				break
+			}
+		}
+	})
+	assert.True(t, allocs <= 1, "only the lexer itself may be allocated, got %v allocations", allocs)
+
+	allocs = testing.AllocsPerRun(100, func() {
+		lex := lexer.New(src)
+		lex.Tokens()
+	})
+	assert.True(t, allocs <= 2, "only the lexer and the token slice may be allocated, got %v allocations", allocs)
+}
+
 func checkInvalidTokens(t *testing.T, src string, expectErr error) {
 	tokens, err := lexer.New(src).Tokens()
 	require.Error(t, err)
@@ -1,23 +1,16 @@
 package lexer
 
 import (
-	"fmt"
-	"regexp"
+	"strconv"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 
 	"github.com/spy16/parens/lexer/utfstrings"
 )
 
-var numberRegex = regexp.MustCompile("^(\\+|-)?\\d+(\\.\\d+)?$")
-
 func scanKeyWord(cur *utfstrings.Cursor) {
-	for {
-		if ru := cur.Next(); isSepratingChar(ru) || ru == utfstrings.EOS {
-			cur.Backup()
-			break
-		}
-	}
+	scanTillSeparator(cur)
 }
 
 // scanComment advances the cursor till line-break or eof.
@@ -30,101 +23,342 @@ func scanComment(cur *utfstrings.Cursor) {
 	}
 }
 
-// scanNumber advances the cursor till a delimiting character
-// is reached and collects all characters. If the collected
-// characters don't match a number regex, returns false.
+// scanNumber advances the cursor till a delimiting character is reached.
+// If the characters don't form a valid number, returns false.
 func scanNumber(cur *utfstrings.Cursor) bool {
-	numStr := ""
-	for {
-		ru := cur.Next()
-		if isSepratingChar(ru) || ru == utfstrings.EOS {
-			cur.Backup()
-			break
+	start := cur.Pos
+	scanTillSeparator(cur)
+	return isNumber(cur.String[start:cur.Pos])
+}
+
+// isNumber returns true if str is a decimal integer, a float with optional
+// exponent, a hex (0x), octal (0o) or binary (0b) integer, a ratio (3/4)
+// or a big integer (123N). All forms can have an optional sign and a '_'
+// between digits.
+func isNumber(str string) bool {
+	i := 0
+	if i < len(str) && (str[i] == '+' || str[i] == '-') {
+		i++
+	}
+
+	if len(str)-i >= 2 && str[i] == '0' {
+		if isDigit := radixDigit(str[i+1]); isDigit != nil {
+			end := scanDigits(str, i+2, isDigit)
+			if end == i+2 {
+				return false
+			}
+			if end < len(str) && str[end] == 'N' {
+				end++
+			}
+			return end == len(str)
 		}
-		numStr = fmt.Sprintf("%s%c", numStr, ru)
 	}
-	if numberRegex.MatchString(numStr) {
+
+	end := scanDigits(str, i, isDecimal)
+	if end == i {
+		return false
+	} else if end == len(str) {
 		return true
 	}
 
-	return false
+	switch str[end] {
+	case 'N':
+		return end+1 == len(str)
+
+	case '/':
+		denom := scanDigits(str, end+1, isDecimal)
+		return denom > end+1 && denom == len(str)
+
+	case '.':
+		frac := scanDigits(str, end+1, isDecimal)
+		if frac == end+1 {
+			return false
+		}
+		end = frac
+	}
+
+	if end < len(str) && (str[end] == 'e' || str[end] == 'E') {
+		end++
+		if end < len(str) && (str[end] == '+' || str[end] == '-') {
+			end++
+		}
+
+		exp := scanDigits(str, end, isDecimal)
+		if exp == end {
+			return false
+		}
+		end = exp
+	}
+
+	return end == len(str)
 }
 
-// scanSymbol advances the cursor until delimiting character is
-// reached or a invalid rune is reached. resets the cursor position
-// in case of invalid rune.
-func scanSymbol(cur *utfstrings.Cursor) bool {
-	runes := []rune{}
-	for {
-		ru := cur.Next()
-		if ru == utfstrings.EOS {
-			break
-		} else if isSepratingChar(ru) {
-			cur.Backup()
+// scanDigits returns the index of the first non-digit character in str
+// starting at i. A single '_' is allowed between digits.
+func scanDigits(str string, i int, isDigit func(c byte) bool) int {
+	if i >= len(str) || !isDigit(str[i]) {
+		return i
+	}
+
+	for i++; i < len(str); i++ {
+		if str[i] == '_' && i+1 < len(str) && isDigit(str[i+1]) {
+			i++
+		} else if !isDigit(str[i]) {
 			break
-		} else if !utf8.ValidRune(ru) {
-			return false
 		}
-		runes = append(runes, ru)
 	}
+	return i
+}
+
+func radixDigit(prefix byte) func(c byte) bool {
+	switch prefix {
+	case 'x', 'X':
+		return isHex
+	case 'o', 'O':
+		return isOctal
+	case 'b', 'B':
+		return isBinary
+	}
+	return nil
+}
+
+func isDecimal(c byte) bool { return '0' <= c && c <= '9' }
+
+func isHex(c byte) bool {
+	return isDecimal(c) || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
 
-	if unicode.IsDigit(runes[0]) {
+func isOctal(c byte) bool { return '0' <= c && c <= '7' }
+
+func isBinary(c byte) bool { return c == '0' || c == '1' }
+
+// scanSymbol advances the cursor until delimiting character is
+// reached. Returns false if the symbol starts with a digit or is not
+// valid UTF-8.
+func scanSymbol(cur *utfstrings.Cursor) bool {
+	start := cur.Pos
+	scanTillSeparator(cur)
+
+	symbol := cur.String[start:cur.Pos]
+	if !utf8.ValidString(symbol) {
 		return false
 	}
 
-	return true
+	ru, _ := utf8.DecodeRuneInString(symbol)
+	return !unicode.IsDigit(ru)
 }
 
+// scanString advances the cursor till the closing double-quote. Escape
+// sequences follow Go string literal syntax (\a \b \f \n \r \t \v \\ \"
+// \ooo \xhh \uhhhh \Uhhhhhhhh) and any other escape sequence results in
+// an error.
 func scanString(cur *utfstrings.Cursor) error {
 	cur.Next() // consume double-quote
 
+	// scanning continues till the end of the string even after an invalid
+	// escape sequence so that lexing can resume after the string.
+	var escapeErr error
 	for {
 		ru := cur.Next()
 		if ru == '\\' {
-			nextRune := cur.Peek()
-			if nextRune == '"' || nextRune == 't' || nextRune == 'n' || nextRune == 'r' {
-				cur.Next()
+			escape := cur.String[cur.Pos-1:]
+			_, _, tail, err := strconv.UnquoteChar(escape, '"')
+			if err != nil {
+				if escapeErr == nil {
+					escapeErr = &ErrInvalidEscape{seq: escapeSeq(escape)}
+				}
+				continue
 			}
+			cur.Pos += len(escape) - len(tail) - 1
+			continue
 		}
 
 		if ru == '"' {
-			return nil
+			return escapeErr
 		}
 
 		if ru == utfstrings.EOS {
+			if escapeErr != nil {
+				return escapeErr
+			}
 			return ErrUnterminatedString
 		}
+	}
+}
 
+// scanRawString advances the cursor till the closing back-quote. Raw
+// strings can span multiple lines and have no escape sequences.
+func scanRawString(cur *utfstrings.Cursor) error {
+	cur.Next() // consume back-quote
+
+	for {
+		switch cur.Next() {
+		case '`':
+			return nil
+
+		case utfstrings.EOS:
+			return ErrUnterminatedString
+		}
 	}
 }
 
-// scanInvalidToken scans the current unidentified token and returns
-// an error.
-func scanInvalidToken(cur *utfstrings.Cursor) error {
-	oldSel := cur.Selection
-	unrec := ""
+// charNames maps the names that can be used in character literals (e.g.
+// \newline) to the characters they represent.
+var charNames = map[string]rune{
+	"newline":   '\n',
+	"space":     ' ',
+	"tab":       '\t',
+	"return":    '\r',
+	"backspace": '\b',
+	"formfeed":  '\f',
+}
+
+// CharacterName returns the name of the character that can be used in a
+// character literal (e.g. "newline" for '\n').
+func CharacterName(ru rune) (string, bool) {
+	for name, char := range charNames {
+		if char == ru {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// scanCharacter advances the cursor over a character literal which is a
+// backslash followed by a single character, a character name (charNames),
+// 'u' followed by 4 hex digits or 'o' followed by 1 to 3 octal digits.
+func scanCharacter(cur *utfstrings.Cursor) error {
+	start := cur.Pos
+	cur.Next() // consume backslash
+
+	if ru := cur.Next(); ru == utfstrings.EOS || unicode.IsSpace(ru) {
+		return &ErrUnrecognizedToken{val: cur.String[start:cur.Pos]}
+	}
+
+	scanTillSeparator(cur)
+
+	val := cur.String[start:cur.Pos]
+	if _, err := ParseCharacter(val); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ParseCharacter returns the character represented by the character
+// literal.
+func ParseCharacter(lit string) (rune, error) {
+	str := strings.TrimPrefix(lit, "\\")
+	if ru, width := utf8.DecodeRuneInString(str); width == len(str) && ru != utf8.RuneError {
+		return ru, nil
+	}
+
+	if ru, found := charNames[str]; found {
+		return ru, nil
+	}
+
+	if len(str) == 5 && str[0] == 'u' {
+		code, err := strconv.ParseUint(str[1:], 16, 32)
+		if err == nil && utf8.ValidRune(rune(code)) {
+			return rune(code), nil
+		}
+	}
+
+	if len(str) >= 2 && len(str) <= 4 && str[0] == 'o' {
+		code, err := strconv.ParseUint(str[1:], 8, 32)
+		if err == nil && code <= 0377 {
+			return rune(code), nil
+		}
+	}
+
+	return 0, &ErrUnrecognizedToken{val: lit}
+}
+
+// scanDispatch advances the cursor over a '#' dispatch sequence. '#"'
+// starts a regex literal which is scanned entirely. '#' followed by a
+// letter starts a tagged literal and results in a DISPATCH token with just
+// the '#'. '#' followed by any other character (except whitespace) results
+// in a DISPATCH token containing both the characters (e.g. '#(', '#{').
+func scanDispatch(cur *utfstrings.Cursor) (TokenType, error) {
+	cur.Next() // consume '#'
+
+	switch ru := cur.Peek(); {
+	case ru == '"':
+		return REGEX, scanRegex(cur)
+
+	case unicode.IsLetter(ru):
+		// tagged literal. tag is scanned as a separate symbol.
+		return DISPATCH, nil
+
+	case ru == utfstrings.EOS || unicode.IsSpace(ru):
+		cur.Pos-- // include '#' in the error
+		return 0, scanInvalidToken(cur)
+
+	default:
+		cur.Next()
+		return DISPATCH, nil
+	}
+}
+
+// scanRegex advances the cursor till the closing double-quote. Backslash
+// escapes the next character but escape sequences are left for the regex
+// compiler to interpret.
+func scanRegex(cur *utfstrings.Cursor) error {
+	cur.Next() // consume double-quote
+
 	for {
-		ru := cur.Next()
-		if ru == utfstrings.EOS || isSepratingChar(ru) {
-			cur.Selection = oldSel
-			break
+		switch cur.Next() {
+		case '\\':
+			if cur.Next() == utfstrings.EOS {
+				return ErrUnterminatedString
+			}
+
+		case '"':
+			return nil
+
+		case utfstrings.EOS:
+			return ErrUnterminatedString
 		}
+	}
+}
 
-		unrec = fmt.Sprintf("%s%c", unrec, ru)
+// escapeSeq returns the escape sequence at the beginning of the string
+// for error reporting.
+func escapeSeq(str string) string {
+	if len(str) < 2 {
+		return str
 	}
 
+	_, width := utf8.DecodeRuneInString(str[1:])
+	return str[:1+width]
+}
+
+// scanInvalidToken scans the current unidentified token and returns
+// an error.
+func scanInvalidToken(cur *utfstrings.Cursor) error {
+	oldSel := cur.Selection
+	scanTillSeparator(cur)
+	unrec := cur.String[oldSel.Pos:cur.Pos]
+	cur.Selection = oldSel
+
 	return &ErrUnrecognizedToken{val: unrec}
 }
 
-func oneOf(ru rune, set ...rune) bool {
-	for _, rs := range set {
-		if ru == rs {
-			return true
+// scanTillSeparator advances the cursor till a separating character or the
+// end of the string.
+func scanTillSeparator(cur *utfstrings.Cursor) {
+	for {
+		if ru := cur.Next(); ru == utfstrings.EOS || isSepratingChar(ru) {
+			cur.Backup()
+			return
 		}
 	}
-	return false
 }
 
 func isSepratingChar(ru rune) bool {
-	return oneOf(ru, ' ', '\t', '\n', '\r', '(', ')', '[', ']', '{', '}')
+	switch ru {
+	case ' ', '\t', '\n', '\r', '(', ')', '[', ']', '{', '}':
+		return true
+	}
+	return false
 }
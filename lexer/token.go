@@ -16,49 +16,100 @@ func (token Token) String() string {
 		token.Type, token.Start, token.Start+len(token.Value), token.Value)
 }
 
+// IsTrivia returns true if the token has no meaning to the parser (i.e.,
+// whitespaces, newlines and comments).
+func (token Token) IsTrivia() bool {
+	return token.Type == WHITESPACE || token.Type == NEWLINE || token.Type == COMMENT
+}
+
 // TokenType represents the type of the extracted token
-type TokenType string
+type TokenType uint8
+
+func (tt TokenType) String() string {
+	if int(tt) < len(tokenNames) && tokenNames[tt] != "" {
+		return tokenNames[tt]
+	}
+	return "INVALID"
+}
 
 const (
 	// LPAREN represents the left-parenthesis character
-	LPAREN TokenType = "LPAREN"
+	LPAREN TokenType = iota + 1
 
 	// RPAREN represents the right-parenthesis character
-	RPAREN TokenType = "RPAREN"
+	RPAREN
 
 	// LVECT represents the left-brace character
-	LVECT TokenType = "LVECT"
+	LVECT
 
 	// RVECT represents the right-brace character
-	RVECT TokenType = "RVECT"
+	RVECT
 
 	// LDICT represents the left curly brace
-	LDICT TokenType = "LDICT"
+	LDICT
 
 	// KEYWORD represents a keyword
-	KEYWORD TokenType = "KEYWORD"
+	KEYWORD
 
 	// RDICT represents the right curly brace
-	RDICT TokenType = "RDICT"
+	RDICT
+
+	// STRING represents a double-quoted string or a back-quoted raw
+	// string
+	STRING
 
-	// STRING represents a double-quoted string
-	STRING TokenType = "STRING"
+	// CHARACTER represents a character literal (e.g. \a, \newline, \u03C0)
+	CHARACTER
 
 	// NUMBER represents int, float, hex, complex etc.
-	NUMBER TokenType = "NUMBER"
+	NUMBER
 
 	// WHITESPACE represents a space, tab or newline
-	WHITESPACE TokenType = "WHITESPACE"
+	WHITESPACE
 
 	// NEWLINE represents a new-line or return-line-feed character.
-	NEWLINE TokenType = "NEWLINE"
+	NEWLINE
 
 	// COMMENT represents a ";" based comment
-	COMMENT TokenType = "COMMENT"
+	COMMENT
 
 	// SYMBOL represents any identifier
-	SYMBOL TokenType = "SYMBOL"
+	SYMBOL
 
 	// QUOTE represents the single quote
-	QUOTE TokenType = "QUOTE"
+	QUOTE
+
+	// DISPATCH represents the '#' character followed by another character
+	// (e.g. '#(', '#{', '#_') that changes the meaning of the following
+	// form. Value is just '#' for tagged literals (e.g. '#inst') in which
+	// case the tag follows as a SYMBOL.
+	DISPATCH
+
+	// MACRO represents a reader macro character registered with the lexer
+	// (see Lexer.MacroChars)
+	MACRO
+
+	// REGEX represents a regular expression literal (e.g. #"\d+")
+	REGEX
 )
+
+var tokenNames = [...]string{
+	LPAREN:     "LPAREN",
+	RPAREN:     "RPAREN",
+	LVECT:      "LVECT",
+	RVECT:      "RVECT",
+	LDICT:      "LDICT",
+	KEYWORD:    "KEYWORD",
+	RDICT:      "RDICT",
+	STRING:     "STRING",
+	CHARACTER:  "CHARACTER",
+	NUMBER:     "NUMBER",
+	WHITESPACE: "WHITESPACE",
+	NEWLINE:    "NEWLINE",
+	COMMENT:    "COMMENT",
+	SYMBOL:     "SYMBOL",
+	QUOTE:      "QUOTE",
+	DISPATCH:   "DISPATCH",
+	MACRO:      "MACRO",
+	REGEX:      "REGEX",
+}
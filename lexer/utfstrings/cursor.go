@@ -27,6 +27,12 @@ func (cur *Cursor) Next() rune {
 		cur.width = 0
 		return EOS
 	}
+	if c := cur.String[cur.Pos]; c < utf8.RuneSelf {
+		cur.width = 1
+		cur.Pos++
+		return rune(c)
+	}
+
 	ru, width := utf8.DecodeRuneInString(cur.String[cur.Pos:])
 	cur.width = width
 	cur.Pos += cur.width
@@ -27,6 +27,20 @@ func TestCursor_Next(suite *testing.T) {
 		assert.Equal(t, utfstrings.EOS, cur.Next())
 	})
 
+	suite.Run("WithMixedRunes", func(t *testing.T) {
+		cur := utfstrings.Cursor{
+			String: "a≠b",
+		}
+		assert.Equal(t, 'a', cur.Next())
+		assert.Equal(t, '≠', cur.Next())
+		cur.Backup()
+		assert.Equal(t, '≠', cur.Next())
+		assert.Equal(t, 'b', cur.Next())
+		cur.Backup()
+		assert.Equal(t, 'b', cur.Next())
+		assert.Equal(t, utfstrings.EOS, cur.Next())
+	})
+
 	suite.Run("WithMultiRunes", func(t *testing.T) {
 		cur := utfstrings.Cursor{
 			String: "≠∂",
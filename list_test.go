@@ -0,0 +1,86 @@
+package parens_test
+
+import (
+	"testing"
+
+	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_Lists(suite *testing.T) {
+	suite.Parallel()
+
+	sym := value.ParseSymbol
+
+	cases := map[string]interface{}{
+		`'(1 2 3)`:                     value.NewList(int64(1), int64(2), int64(3)),
+		`'()`:                          value.NewList(),
+		`()`:                           value.NewList(),
+		`'(+ a [b] "c")`:               value.NewList(sym("+"), sym("a"), vecOf(sym("b")), "c"),
+		`'(a 'b)`:                      value.NewList(sym("a"), value.NewList(sym("quote"), sym("b"))),
+		`(list 1 (+ 1 1))`:             value.NewList(int64(1), int64(2)),
+		`(cons 1 '(2 3))`:              value.NewList(int64(1), int64(2), int64(3)),
+		`(== (cons 1 [2 3]) '(1 2 3))`: true,
+		`(cons 1 ())`:                  value.NewList(int64(1)),
+		`(first '(1 2))`:               int64(1),
+		`(first [1 2])`:                int64(1),
+		`(first ())`:                   nil,
+		`(rest '(1 2 3))`:              value.NewList(int64(2), int64(3)),
+		`(== (rest [1]) ())`:           true,
+		`(rest ())`:                    value.NewList(),
+		`(conj '(1 2) 3 4)`:            value.NewList(int64(4), int64(3), int64(1), int64(2)),
+		`(conj [1 2] 3 4)`:             vecOf(int64(1), int64(2), int64(3), int64(4)),
+		`(conj #{1} 2 1)`:              setOf(int64(1), int64(2)),
+		`(conj {:a 1} [:b 2])`:         mapOf(kw(":a"), int64(1), kw(":b"), int64(2)),
+		`(list? '(1))`:                 true,
+		`(list? [1])`:                  false,
+		`(== '(1 [2]) (list 1 [2]))`:   true,
+		`(pr-str '(a "b" \c (d)))`:     `(a "b" \c (d))`,
+		`(eval '(+ 1 2))`:              int64(3),
+		`(eval (cons '+ '(1 2 3)))`:    int64(6),
+		`(eval (list 'apply '+ [1 (list '+ 1 1)]))`: int64(3),
+		`(eval ''a)`:                        sym("a"),
+		`(eval '[(+ 1 1) {:k (+ 1 2)}])`:    vecOf(int64(2), mapOf(kw(":k"), int64(3))),
+		`(eval '(do (label x 10) (+ x 1)))`: int64(11),
+		`(eval 10)`:                         int64(10),
+		`(apply + '(1 2 3))`:                int64(6),
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			res, err := executeWithStdlib(t, src)
+			require.NoError(t, err)
+			assert.Equal(t, expected, res)
+		})
+	}
+
+	suite.Run("Definitions", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `(do (eval (list 'defn 'inc [(symbol "x")] (list '+ 'x 1))) (inc 41))`)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), res)
+	})
+
+	suite.Run("FormExpr", func(t *testing.T) {
+		forms, err := parser.ReadData("<test>", `(if-not (== 1 2) "yes" 'no)`)
+		require.NoError(t, err)
+		assert.Equal(t, `(if-not (== 1 2) "yes" (quote no))`, parser.Repr(forms[0]))
+		assert.Equal(t, `(if-not (== 1 2) "yes" 'no)`, parser.Repr(parser.FormExpr(forms[0])))
+	})
+
+	suite.Run("Errors", func(t *testing.T) {
+		for _, src := range []string{
+			`(cons 1)`,
+			`(cons 1 2)`,
+			`(first 1)`,
+			`(conj 1 2)`,
+			`(conj {} [1])`,
+			`(eval '(undefined-fn 1))`,
+		} {
+			_, err := executeWithStdlib(t, src)
+			assert.Error(t, err, src)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package parens_test
+
+import (
+	"testing"
+
+	"github.com/spy16/parens"
+	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/stdlib"
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_MapLiterals(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("ArbitraryKeys", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `{"a" 1 2 "two" \c 3.0 :d [4] true nil}`)
+		require.NoError(t, err)
+		assert.Equal(t, mapOf("a", int64(1), int64(2), "two", 'c', 3.0, kw(":d"), vecOf(int64(4)), true, nil), res)
+
+		m := res.(*value.Map)
+		val, found := m.Get(int64(2))
+		assert.True(t, found)
+		assert.Equal(t, "two", val)
+	})
+
+	suite.Run("EvaluatedKeys", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `(do (label k "key") {k (+ 1 2)})`)
+		require.NoError(t, err)
+		assert.Equal(t, mapOf("key", int64(3)), res)
+	})
+
+	suite.Run("SourceOrder", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `{:z 1 :a 2 "m" 3 1 4}`)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{kw(":z"), kw(":a"), "m", int64(1)}, res.(*value.Map).Keys())
+		assert.Equal(t, `{:z 1 :a 2 "m" 3 1 4}`, parser.Repr(res))
+	})
+
+	suite.Run("EvaluationOrder", func(t *testing.T) {
+		var order []string
+		scope := parens.NewScope(nil)
+		require.NoError(t, stdlib.RegisterAll(scope))
+		scope.Bind("trace", func(name string) string {
+			order = append(order, name)
+			return name
+		})
+
+		_, err := parens.New(scope).Execute(`{(trace "k1") (trace "v1") (trace "k2") (trace "v2") (trace "k3") (trace "v3")}`)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"k1", "v1", "k2", "v2", "k3", "v3"}, order)
+	})
+
+	suite.Run("DuplicateLiteralKeys", func(t *testing.T) {
+		for _, src := range []string{`{:a 1 :a 2}`, `{"a" 1 "a" 2}`, `{1 :x 1 :y}`, "{\"a\" 1 `a` 2}", `{k 1 k 2}`} {
+			_, err := parser.Parse("<test>", src)
+			assert.Error(t, err, src)
+		}
+	})
+
+	suite.Run("DuplicateEvaluatedKeys", func(t *testing.T) {
+		_, err := executeWithStdlib(t, `(do (label k :a) {k 1 :a 2})`)
+		assert.EqualError(t, err, "duplicate map key ':a'")
+	})
+
+	suite.Run("CollectionKeys", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `{[1 2] :vec #{3} :set {:a 1} :map}`)
+		require.NoError(t, err)
+
+		m := res.(*value.Map)
+		for key, expected := range map[string]interface{}{"[1 2]": kw(":vec"), "#{3}": kw(":set"), "{:a 1}": kw(":map")} {
+			keyVal, err := executeWithStdlib(t, key)
+			require.NoError(t, err)
+
+			val, found := m.Get(keyVal)
+			assert.True(t, found, key)
+			assert.Equal(t, expected, val, key)
+		}
+
+		_, err = executeWithStdlib(t, `{[1 2] 1 [1 2] 2}`)
+		assert.Error(t, err)
+	})
+
+	suite.Run("UnhashableKey", func(t *testing.T) {
+		_, err := executeWithStdlib(t, `{(make-slice "int" 2) 3}`)
+		assert.EqualError(t, err, "value of type '[]int' cannot be a map key")
+	})
+
+	suite.Run("QuotedString", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `'{:b 1 :a (+ 1 2)}`)
+		require.NoError(t, err)
+		assert.Equal(t, `{:b 1 :a (+ 1 2)}`, parser.Repr(res))
+	})
+}
@@ -2,6 +2,7 @@ package parens
 
 import (
 	"fmt"
+	"io"
 	"io/ioutil"
 	"strings"
 
@@ -25,13 +26,8 @@ func New(scope parser.Scope) *Interpreter {
 		return val
 	}
 
-	evalStr := func(val interface{}) interface{} {
-		expr, ok := val.(parser.Expr)
-		if !ok {
-			return val
-		}
-
-		res, err := expr.Eval(exec.Scope)
+	evalForm := func(form interface{}) interface{} {
+		res, err := parser.FormExpr(form).Eval(exec.Scope)
 		if err != nil {
 			panic(err)
 		}
@@ -44,8 +40,8 @@ func New(scope parser.Scope) *Interpreter {
 		"Example: (load \"sample.lisp\")",
 	)
 
-	scope.Bind("eval", evalStr,
-		"Executes given LISP string in the current scope",
+	scope.Bind("eval", evalForm,
+		"Evaluates the form (e.g. a quoted list) in the current scope",
 		"Usage: (eval <form>)",
 	)
 	return exec
@@ -82,6 +78,30 @@ func (parens *Interpreter) ExecuteExpr(expr parser.Expr) (interface{}, error) {
 	return expr.Eval(parens.Scope)
 }
 
+// ExecuteReader reads and executes forms from r one at a time until the
+// end of r is reached and returns the result of the last form. Unlike
+// Execute, forms are executed as soon as they are read and the source is
+// never held in memory as a whole.
+func (parens *Interpreter) ExecuteReader(name string, r io.Reader) (interface{}, error) {
+	rd := parser.NewReader(name, r)
+
+	var res interface{}
+	for {
+		expr, err := rd.Read()
+		if err != nil {
+			if err == io.EOF {
+				return res, nil
+			}
+			return nil, err
+		}
+
+		res, err = parens.evalSafely(expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
 func (parens *Interpreter) executeSrc(name, src string) (interface{}, error) {
 	src = strings.TrimSpace(src)
 	expr, err := parens.Parse(name, src)
@@ -89,27 +109,26 @@ func (parens *Interpreter) executeSrc(name, src string) (interface{}, error) {
 		return nil, err
 	}
 
-	var res interface{}
-	var evalErr error
-	safeWrapper := func() {
-		defer func() {
-			if v := recover(); v != nil {
-				if err, ok := v.(error); ok {
-					evalErr = err
-				} else {
-					evalErr = fmt.Errorf("panic: %v", v)
-				}
-			}
-		}()
+	return parens.evalSafely(expr)
+}
 
-		res, err = expr.Eval(parens.Scope)
-		evalErr = err
-	}
+// evalSafely evaluates the expr and turns panics during evaluation into
+// errors.
+func (parens *Interpreter) evalSafely(expr parser.Expr) (res interface{}, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			res = nil
+			if e, ok := v.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("panic: %v", v)
+			}
+		}
+	}()
 
-	safeWrapper()
-	if evalErr != nil {
-		return nil, evalErr
+	res, err = expr.Eval(parens.Scope)
+	if err != nil {
+		return nil, err
 	}
-
 	return res, nil
 }
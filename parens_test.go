@@ -2,6 +2,7 @@ package parens_test
 
 import (
 	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/spy16/parens"
@@ -65,6 +66,155 @@ func BenchmarkParens_FunctionCall(suite *testing.B) {
 	})
 }
 
+func BenchmarkParens_NativeCall(suite *testing.B) {
+	nativeAdd := func(args ...interface{}) (interface{}, error) {
+		return args[0].(int64) + args[1].(int64), nil
+	}
+
+	expr, err := parser.Parse("<test>", "(add 1 2)")
+	if err != nil {
+		suite.Fatalf("failed to parse expression: %s", err)
+	}
+
+	suite.Run("Reflection", func(b *testing.B) {
+		scope := parens.NewScope(nil)
+		scope.Bind("add", add)
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			expr.Eval(scope)
+		}
+	})
+
+	suite.Run("NativeFunc", func(b *testing.B) {
+		scope := parens.NewScope(nil)
+		scope.Bind("add", parser.NativeFunc(nativeAdd))
+
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			expr.Eval(scope)
+		}
+	})
+}
+
+func TestExecute_NativeFunc(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("NativeFunc", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		scope.Bind("first", parser.NativeFunc(func(args ...interface{}) (interface{}, error) {
+			return args[0], nil
+		}))
+
+		res, err := parens.New(scope).Execute(`(first "a" "b")`)
+		require.NoError(t, err)
+		assert.Equal(t, "a", res)
+	})
+
+	suite.Run("NativeFuncError", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		scope.Bind("fail", func(args ...interface{}) (interface{}, error) {
+			return nil, errors.New("failed")
+		})
+
+		_, err := parens.New(scope).Execute(`(fail)`)
+		assert.EqualError(t, err, "failed")
+	})
+
+	suite.Run("Invokable", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		scope.Bind("count", countInvokable{})
+
+		res, err := parens.New(scope).Execute(`(count 1 2 3)`)
+		require.NoError(t, err)
+		assert.Equal(t, 3, res)
+	})
+}
+
+func TestExecute_NumberLiterals(suite *testing.T) {
+	suite.Parallel()
+
+	bigInt, _ := new(big.Int).SetString("12345678901234567890", 10)
+
+	cases := map[string]interface{}{
+		"10":                     int64(10),
+		"-10":                    int64(-10),
+		"1_000_000":              int64(1000000),
+		"0xFF":                   int64(255),
+		"-0x10":                  int64(-16),
+		"0o17":                   int64(15),
+		"0b1010":                 int64(10),
+		"017":                    int64(17),
+		"1.5":                    1.5,
+		"1e3":                    1000.0,
+		"2.5e-1":                 0.25,
+		"3/4":                    big.NewRat(3, 4),
+		"-6/8":                   big.NewRat(-3, 4),
+		"4/2":                    int64(2),
+		"10N":                    big.NewInt(10),
+		"0xFFN":                  big.NewInt(255),
+		"12345678901234567890":   bigInt,
+		"12345678901234567890N":  bigInt,
+		"-12345678901234567890N": new(big.Int).Neg(bigInt),
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			res, err := parens.New(parens.NewScope(nil)).Execute(src)
+			require.NoError(t, err)
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestExecute_StringLiterals(suite *testing.T) {
+	suite.Parallel()
+
+	cases := map[string]string{
+		`"hello"`:               "hello",
+		`"tab\there"`:           "tab\there",
+		`"back\\slash"`:         `back\slash`,
+		`"\"quoted\""`:          `"quoted"`,
+		`"\x41\102é\U0001F600"`: "ABé\U0001F600",
+		"\"multi\nline\"":       "multi\nline",
+		"`raw \\d+ \"str\"`":    `raw \d+ "str"`,
+		"`multi\nline raw\\n`":  "multi\nline raw\\n",
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			res, err := parens.New(parens.NewScope(nil)).Execute(src)
+			require.NoError(t, err)
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestExecute_CharacterLiterals(suite *testing.T) {
+	suite.Parallel()
+
+	cases := map[string]rune{
+		`\a`:       'a',
+		`\π`:       'π',
+		`\newline`: '\n',
+		`\space`:   ' ',
+		`\tab`:     '\t',
+		`\u03C0`:   'π',
+		`\o101`:    'A',
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			res, err := parens.New(parens.NewScope(nil)).Execute(src)
+			require.NoError(t, err)
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
 func TestExecute_Success(t *testing.T) {
 	scope := parens.NewScope(nil)
 	par := parens.New(scope)
@@ -121,3 +271,9 @@ type exprMock func(scope parser.Scope) (interface{}, error)
 func (sm exprMock) Eval(scope parser.Scope) (interface{}, error) {
 	return sm(scope)
 }
+
+type countInvokable struct{}
+
+func (ci countInvokable) Invoke(scope parser.Scope, args ...interface{}) (interface{}, error) {
+	return len(args), nil
+}
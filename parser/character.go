@@ -0,0 +1,32 @@
+package parser
+
+import (
+	"github.com/spy16/parens/lexer"
+)
+
+func newCharacterExpr(token *lexer.Token) (CharacterExpr, error) {
+	ru, err := lexer.ParseCharacter(token.Value)
+	if err != nil {
+		return CharacterExpr{}, err
+	}
+
+	return CharacterExpr{
+		Char: token.Value,
+		Rune: ru,
+	}, nil
+}
+
+// CharacterExpr represents a character literal (e.g. \a, \newline).
+type CharacterExpr struct {
+	Char string
+	Rune rune
+}
+
+// Eval returns the character as a rune.
+func (ce CharacterExpr) Eval(scope Scope) (interface{}, error) {
+	return ce.Rune, nil
+}
+
+func (ce CharacterExpr) String() string {
+	return ce.Char
+}
@@ -0,0 +1,240 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/spy16/parens/lexer"
+)
+
+// NodeKind represents the kind of a node in the concrete syntax tree.
+type NodeKind int
+
+// Kinds of the nodes in concrete syntax tree.
+const (
+	// ModuleNode is the root node containing all the top-level forms.
+	ModuleNode NodeKind = iota
+
+	// AtomNode is a single token form (e.g. symbol, number, string).
+	AtomNode
+
+	// ListNode, VectorNode, MapNode, SetNode and FnNode are collections
+	// with the forms between the opening and closing tokens as children.
+	ListNode
+	VectorNode
+	MapNode
+	SetNode
+	FnNode
+
+	// QuoteNode, DiscardNode and MacroNode are prefixes ("'", "#_" and
+	// reader macros) with the form following the prefix as the child.
+	QuoteNode
+	DiscardNode
+	MacroNode
+
+	// TaggedNode is a tagged literal with the tag symbol and the literal
+	// form as children.
+	TaggedNode
+)
+
+var nodeKindNames = [...]string{
+	ModuleNode:  "Module",
+	AtomNode:    "Atom",
+	ListNode:    "List",
+	VectorNode:  "Vector",
+	MapNode:     "Map",
+	SetNode:     "Set",
+	FnNode:      "Fn",
+	QuoteNode:   "Quote",
+	DiscardNode: "Discard",
+	MacroNode:   "Macro",
+	TaggedNode:  "Tagged",
+}
+
+func (kind NodeKind) String() string {
+	return nodeKindNames[kind]
+}
+
+// Node is a node in the concrete syntax tree. Unlike the Expr tree, it
+// retains all the whitespaces, newlines and comments (trivia) so that the
+// exact source can be reproduced using String().
+type Node struct {
+	Kind NodeKind
+
+	// Leading is the trivia that precedes the node.
+	Leading []lexer.Token
+
+	// Token is the token of an atom or the opening token of other nodes
+	// (e.g. '(', '#{', "'", '#_'). Not set for the module node.
+	Token lexer.Token
+
+	// Children are the nested nodes in the order they appear.
+	Children []*Node
+
+	// Trailing is the trivia between the last child and the closing token
+	// of collections or the end of the source for the module node.
+	Trailing []lexer.Token
+
+	// Close is the closing token of collections.
+	Close *lexer.Token
+}
+
+// String returns the exact source the node was parsed from.
+func (node *Node) String() string {
+	var sb strings.Builder
+	node.write(&sb)
+	return sb.String()
+}
+
+func (node *Node) write(sb *strings.Builder) {
+	writeTokens(sb, node.Leading)
+	if node.Kind != ModuleNode {
+		sb.WriteString(node.Token.Value)
+	}
+
+	for _, child := range node.Children {
+		child.write(sb)
+	}
+
+	writeTokens(sb, node.Trailing)
+	if node.Close != nil {
+		sb.WriteString(node.Close.Value)
+	}
+}
+
+// ParseCST parses the src into a concrete syntax tree using the
+// DefaultMacros. Syntax errors are reported the same way as Parse, but
+// tagged literals are not read, so invalid literals (e.g. '#inst
+// "yesterday"') are not reported. Reader macros are assumed to
+// consume exactly one form following them.
+func ParseCST(name, src string) (*Node, error) {
+	return defaultParser.ParseCST(name, src)
+}
+
+// ParseCST parses the src into a concrete syntax tree. See ParseCST.
+func (p *Parser) ParseCST(name, src string) (*Node, error) {
+	// only the syntax is checked. tagged literals are not read.
+	if _, err := p.parse(name, src, true); err != nil {
+		return nil, err
+	}
+
+	lex := lexer.New(src)
+	lex.MacroChars = p.Macros.chars()
+
+	tokens, err := lex.Tokens()
+	if err != nil {
+		return nil, err
+	}
+
+	cb := &cstBuilder{tokens: tokens}
+	module := &Node{Kind: ModuleNode}
+	for cb.peek() != nil {
+		module.Children = append(module.Children, cb.node())
+	}
+	module.Trailing = cb.trivia()
+
+	return module, nil
+}
+
+// cstBuilder builds the concrete syntax tree from the tokens. Tokens must
+// be free of syntax errors.
+type cstBuilder struct {
+	tokens []lexer.Token
+	pos    int
+}
+
+func (cb *cstBuilder) node() *Node {
+	node := &Node{
+		Kind:    AtomNode,
+		Leading: cb.trivia(),
+		Token:   cb.tokens[cb.pos],
+	}
+	cb.pos++
+
+	switch node.Token.Type {
+	case lexer.LPAREN:
+		cb.collection(node, ListNode, lexer.RPAREN)
+
+	case lexer.LVECT:
+		cb.collection(node, VectorNode, lexer.RVECT)
+
+	case lexer.LDICT:
+		cb.collection(node, MapNode, lexer.RDICT)
+
+	case lexer.QUOTE:
+		cb.prefix(node, QuoteNode)
+
+	case lexer.MACRO:
+		cb.prefix(node, MacroNode)
+
+	case lexer.DISPATCH:
+		switch node.Token.Value {
+		case "#(":
+			cb.collection(node, FnNode, lexer.RPAREN)
+
+		case "#{":
+			cb.collection(node, SetNode, lexer.RDICT)
+
+		case "#_":
+			cb.prefix(node, DiscardNode)
+
+		case "#":
+			// tag symbol immediately follows the '#'.
+			tag := cb.node()
+			cb.prefix(node, TaggedNode)
+			node.Children = append([]*Node{tag}, node.Children...)
+
+		default:
+			cb.prefix(node, MacroNode)
+		}
+	}
+
+	return node
+}
+
+func (cb *cstBuilder) collection(node *Node, kind NodeKind, closer lexer.TokenType) {
+	node.Kind = kind
+	for {
+		if next := cb.peek(); next.Type == closer {
+			node.Trailing = cb.trivia()
+			node.Close = &cb.tokens[cb.pos]
+			cb.pos++
+			return
+		}
+
+		node.Children = append(node.Children, cb.node())
+	}
+}
+
+func (cb *cstBuilder) prefix(node *Node, kind NodeKind) {
+	node.Kind = kind
+	node.Children = []*Node{cb.node()}
+}
+
+// peek returns the next non-trivia token without consuming anything.
+func (cb *cstBuilder) peek() *lexer.Token {
+	for i := cb.pos; i < len(cb.tokens); i++ {
+		if !cb.tokens[i].IsTrivia() {
+			return &cb.tokens[i]
+		}
+	}
+	return nil
+}
+
+// trivia consumes and returns the trivia tokens at the current position.
+func (cb *cstBuilder) trivia() []lexer.Token {
+	start := cb.pos
+	for cb.pos < len(cb.tokens) && cb.tokens[cb.pos].IsTrivia() {
+		cb.pos++
+	}
+
+	if start == cb.pos {
+		return nil
+	}
+	return cb.tokens[start:cb.pos]
+}
+
+func writeTokens(sb *strings.Builder, tokens []lexer.Token) {
+	for _, token := range tokens {
+		sb.WriteString(token.Value)
+	}
+}
@@ -0,0 +1,121 @@
+package parser
+
+import "github.com/spy16/parens/value"
+
+// ReadData parses the src and returns the forms as plain Go values without
+// evaluating anything. Numbers, strings, characters, keywords and regex
+// literals result in the same values as evaluating them would, 'true',
+// 'false' and 'nil' result in the respective Go values, vectors in
+// *value.Vector, maps in *value.Map and sets in *value.Set.
+// Tagged literals are passed to the readers in DefaultTags and tags without
+// a reader result in TaggedLiteral values. Keywords result
+// in value.Keyword, any other symbol in value.Symbol, lists in *value.List
+// and quoted forms in (quote form) lists. Other code forms (e.g. #()
+// literals) result in the Expr itself. FormExpr turns the data back into
+// an Expr so that it can be evaluated explicitly if required.
+//
+// Symbols are never resolved, lists are never invoked and custom reader
+// macros (which run arbitrary Go code) are not applied. Only the built-in
+// syntax and the registered tag readers are used which makes ReadData
+// suitable for untrusted input (e.g. configuration files) as long as the
+// tag readers are. Keywords and symbols read are interned weakly and do not
+// outlive the data.
+func ReadData(name, src string) ([]interface{}, error) {
+	return (&Parser{Tags: defaultTags}).ReadData(name, src)
+}
+
+// ReadData parses the src using the macros and tags of the parser and
+// returns the forms as data. Unlike the package level ReadData, custom
+// reader macros are applied. See ReadData.
+func (p *Parser) ReadData(name, src string) ([]interface{}, error) {
+	expr, err := p.Parse(name, src)
+	if err != nil {
+		return nil, err
+	}
+
+	return exprsData(expr.(ModuleExpr).Exprs)
+}
+
+// exprData returns the data value represented by the expr.
+func exprData(expr Expr) (interface{}, error) {
+	switch e := expr.(type) {
+	case NumberExpr, StringExpr, CharacterExpr, KeywordExpr, RegexExpr:
+		// these do not use the scope.
+		return e.Eval(nil)
+
+	case SymbolExpr:
+		switch e.Symbol {
+		case "nil":
+			return nil, nil
+
+		case "true":
+			return true, nil
+
+		case "false":
+			return false, nil
+		}
+		return value.ParseSymbol(e.Symbol), nil
+
+	case ListExpr:
+		items, err := exprsData(e.List)
+		if err != nil {
+			return nil, err
+		}
+		return value.NewList(items...), nil
+
+	case QuoteExpr:
+		form, err := exprData(e.expr)
+		if err != nil {
+			return nil, err
+		}
+		return value.NewList(quoteSymbol, form), nil
+
+	case VectorExpr:
+		items, err := exprsData(e.List)
+		if err != nil {
+			return nil, err
+		}
+		return value.NewVector(items...), nil
+
+	case MapExpr:
+		kvs := make([]interface{}, 0, 2*len(e.Keys))
+		for i, keyExpr := range e.Keys {
+			key, err := exprData(keyExpr)
+			if err != nil {
+				return nil, err
+			}
+
+			val, err := exprData(e.Values[i])
+			if err != nil {
+				return nil, err
+			}
+			kvs = append(kvs, key, val)
+		}
+		return newMap(kvs)
+
+	case SetExpr:
+		items, err := exprsData(e.List)
+		if err != nil {
+			return nil, err
+		}
+		return newSet(items)
+
+	case TaggedExpr:
+		return e.Value, nil
+
+	default:
+		return expr, nil
+	}
+}
+
+func exprsData(exprs []Expr) ([]interface{}, error) {
+	vals := make([]interface{}, 0, len(exprs))
+	for _, expr := range exprs {
+		val, err := exprData(expr)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, val)
+	}
+	return vals, nil
+}
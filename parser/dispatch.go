@@ -0,0 +1,263 @@
+package parser
+
+import (
+	"fmt"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spy16/parens/lexer"
+)
+
+// ReaderMacro is invoked by the parser when the character it is registered
+// for is found at the beginning of a form. The macro can read the following
+// tokens or forms using the TokenReader and must return the Expr that the
+// whole form represents. Returning nil Expr (and nil error) discards the
+// form similar to '#_'.
+type ReaderMacro func(rd *TokenReader, token lexer.Token) (Expr, error)
+
+// TokenReader provides access to the token stream for reader macros.
+type TokenReader struct {
+	queue *tokenQueue
+}
+
+// ReadForm reads the next complete form.
+func (rd *TokenReader) ReadForm() (Expr, error) {
+	return buildForm(rd.queue)
+}
+
+// PeekToken returns the next token (ignoring whitespaces, newlines and
+// comments) without consuming it. Returns nil if there are no more tokens.
+func (rd *TokenReader) PeekToken() *lexer.Token {
+	return rd.queue.Token(0)
+}
+
+// NextToken consumes and returns the next token (ignoring whitespaces,
+// newlines and comments). Returns nil if there are no more tokens.
+func (rd *TokenReader) NextToken() *lexer.Token {
+	return rd.queue.Pop()
+}
+
+// Macros is a registry of reader macros and dispatch macros. Every Parser
+// uses its own Macros so that the macros registered by one user of the
+// package do not change how the source of others is parsed.
+type Macros struct {
+	mu       sync.RWMutex
+	reader   map[rune]ReaderMacro
+	dispatch map[rune]ReaderMacro
+}
+
+// NewMacros creates an empty registry.
+func NewMacros() *Macros {
+	return &Macros{
+		reader:   map[rune]ReaderMacro{},
+		dispatch: map[rune]ReaderMacro{},
+	}
+}
+
+var defaultMacros = NewMacros()
+
+// DefaultMacros returns the process-wide registry used by the package-level
+// Parse, ParseCST and NewReader functions. Prefer a Parser with its own
+// Macros when the process may have other users of the package.
+func DefaultMacros() *Macros {
+	return defaultMacros
+}
+
+// RegisterReaderMacro registers a reader macro in the DefaultMacros. See
+// Macros.RegisterReaderMacro.
+func RegisterReaderMacro(ch rune, macro ReaderMacro) error {
+	return defaultMacros.RegisterReaderMacro(ch, macro)
+}
+
+// RegisterDispatchMacro registers a dispatch macro in the DefaultMacros. See
+// Macros.RegisterDispatchMacro.
+func RegisterDispatchMacro(ch rune, macro ReaderMacro) error {
+	return defaultMacros.RegisterDispatchMacro(ch, macro)
+}
+
+// Clone returns a copy of the registry. Registering or unregistering macros
+// in either of them does not affect the other.
+func (m *Macros) Clone() *Macros {
+	clone := NewMacros()
+	if m == nil {
+		return clone
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for ch, macro := range m.reader {
+		clone.reader[ch] = macro
+	}
+	for ch, macro := range m.dispatch {
+		clone.dispatch[ch] = macro
+	}
+	return clone
+}
+
+// RegisterReaderMacro registers a reader macro for the given character. The
+// macro will be invoked whenever a form starts with the character (e.g. '@'
+// in '@counter'). Characters that already have a meaning (e.g. '(', '"',
+// '#') and characters symbols and numbers are made of (letters, digits,
+// '*', '-' etc.) cannot be registered.
+func (m *Macros) RegisterReaderMacro(ch rune, macro ReaderMacro) error {
+	if isReservedMacroChar(ch) {
+		return fmt.Errorf("character '%c' cannot be used as reader macro", ch)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reader[ch] = macro
+	return nil
+}
+
+// RegisterDispatchMacro registers a reader macro for the dispatch sequence
+// formed by '#' and the given character (e.g. '!' for '#!'). Letters are
+// reserved for tagged literals and built-in dispatch sequences ('#(', '#{',
+// '#_', '#"', '##') cannot be overridden.
+func (m *Macros) RegisterDispatchMacro(ch rune, macro ReaderMacro) error {
+	if unicode.IsLetter(ch) || unicode.IsSpace(ch) || ch == '"' || builtinDispatch(ch) {
+		return fmt.Errorf("character '%c' cannot be used as dispatch macro", ch)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.dispatch[ch] = macro
+	return nil
+}
+
+// UnregisterReaderMacro removes the reader macro for the character if one
+// is registered.
+func (m *Macros) UnregisterReaderMacro(ch rune) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.reader, ch)
+}
+
+// UnregisterDispatchMacro removes the dispatch macro for the character if
+// one is registered.
+func (m *Macros) UnregisterDispatchMacro(ch rune) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.dispatch, ch)
+}
+
+// readerMacro returns the reader macro for the character. nil Macros has
+// no macros.
+func (m *Macros) readerMacro(ch rune) (ReaderMacro, bool) {
+	if m == nil {
+		return nil, false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	macro, found := m.reader[ch]
+	return macro, found
+}
+
+func (m *Macros) dispatchMacro(ch rune) (ReaderMacro, bool) {
+	if m == nil {
+		return nil, false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	macro, found := m.dispatch[ch]
+	return macro, found
+}
+
+// chars returns the characters that are registered as reader macros.
+func (m *Macros) chars() map[rune]bool {
+	chars := map[rune]bool{}
+	if m == nil {
+		return chars
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for ch := range m.reader {
+		chars[ch] = true
+	}
+	return chars
+}
+
+func buildMacroExpr(token *lexer.Token, tokens *tokenQueue) (Expr, error) {
+	ch, _ := utf8.DecodeRuneInString(token.Value)
+
+	macro, found := tokens.macros.readerMacro(ch)
+	if !found {
+		return nil, fmt.Errorf("no reader macro for '%s'", token.Value)
+	}
+
+	return macro(&TokenReader{queue: tokens}, *token)
+}
+
+func buildDispatchExpr(token *lexer.Token, tokens *tokenQueue) (Expr, error) {
+	if token.Value == "#" {
+		return buildTaggedExpr(token, tokens)
+	}
+
+	ch, _ := utf8.DecodeRuneInString(token.Value[1:])
+
+	switch ch {
+	case '(':
+		return buildFnExpr(token, tokens)
+
+	case '{':
+		return buildSetExpr(token, tokens)
+
+	case '_':
+		return discardExpr(tokens)
+
+	case '#':
+		return buildSymbolicExpr(token, tokens)
+	}
+
+	macro, found := tokens.macros.dispatchMacro(ch)
+	if !found {
+		return nil, fmt.Errorf("unknown dispatch form '%s'", token.Value)
+	}
+
+	return macro(&TokenReader{queue: tokens}, *token)
+}
+
+// discardExpr reads and ignores the next form. Always returns nil Expr
+// which must be skipped by the callers.
+func discardExpr(tokens *tokenQueue) (Expr, error) {
+	if _, err := buildForm(tokens); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// buildForm is same as buildExpr but skips forms that are discarded using
+// '#_' (or reader macros that return nil).
+func buildForm(tokens *tokenQueue) (Expr, error) {
+	for {
+		expr, err := buildExpr(tokens)
+		if err != nil || expr != nil {
+			return expr, err
+		}
+	}
+}
+
+func builtinDispatch(ch rune) bool {
+	return ch == '(' || ch == '{' || ch == '_' || ch == '#'
+}
+
+// isReservedMacroChar returns true if the character has a meaning in the
+// syntax or is commonly part of symbols and numbers (e.g. 'a' in 'and' or
+// '*' in '*ns*') since a macro on it would capture those tokens.
+func isReservedMacroChar(ch rune) bool {
+	switch ch {
+	case '(', ')', '[', ']', '{', '}', '"', '`', '\'', ';', ':', '#', '\\':
+		return true
+
+	case '+', '-', '*', '/', '<', '>', '=', '!', '?', '_', '.', '%', '&':
+		return true
+	}
+
+	return unicode.IsSpace(ch) || unicode.IsLetter(ch) || unicode.IsDigit(ch) ||
+		unicode.IsNumber(ch) || unicode.IsMark(ch) || ch == utf8.RuneError
+}
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Position represents a location in the source.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (pos Position) String() string {
+	return fmt.Sprintf("%s:%d:%d", pos.File, pos.Line, pos.Column)
+}
+
+// SyntaxError is a problem found while parsing the source.
+type SyntaxError struct {
+	Pos Position
+	Msg string
+}
+
+func (err SyntaxError) Error() string {
+	return fmt.Sprintf("%s: %s", err.Pos, err.Msg)
+}
+
+// ErrorList is returned by Parse when the source has one or more syntax
+// errors. Errors are in the order they appear in the source.
+type ErrorList []*SyntaxError
+
+func (el ErrorList) Error() string {
+	msgs := make([]string, len(el))
+	for i, err := range el {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the syntax errors to allow inspecting them with errors.Is
+// and errors.As.
+func (el ErrorList) Unwrap() []error {
+	errs := make([]error, len(el))
+	for i, err := range el {
+		errs[i] = err
+	}
+	return errs
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spy16/parens/lexer"
+	"github.com/spy16/parens/value"
+)
+
+// FnExpr represents the anonymous function shorthand (e.g. #(+ % 1)). The
+// arguments are available in the body as %1, %2 etc. (% is same as %1) and
+// the remaining arguments as %& in case of a variadic function.
+type FnExpr struct {
+	Arity    int
+	Variadic bool
+	Body     ListExpr
+}
+
+// Eval returns the function as a NativeFunc. Every invocation evaluates the
+// body in a new scope with the arguments bound.
+func (fe FnExpr) Eval(scope Scope) (interface{}, error) {
+	fn := func(args ...interface{}) (interface{}, error) {
+		if len(args) < fe.Arity || (!fe.Variadic && len(args) != fe.Arity) {
+			return nil, fmt.Errorf("requires %d arguments, got %d", fe.Arity, len(args))
+		}
+
+		fnScope := NewScope(scope)
+		for i := 0; i < fe.Arity; i++ {
+			fnScope.Bind("%"+strconv.Itoa(i+1), args[i])
+		}
+
+		if fe.Arity > 0 {
+			fnScope.Bind("%", args[0])
+		}
+
+		if fe.Variadic {
+			fnScope.Bind("%&", value.NewVector(args[fe.Arity:]...))
+		}
+
+		return fe.Body.Eval(fnScope)
+	}
+
+	return NativeFunc(fn), nil
+}
+
+func (fe FnExpr) String() string {
+	return "#" + fe.Body.String()
+}
+
+func buildFnExpr(opener *lexer.Token, tokens *tokenQueue) (Expr, error) {
+	body := ListExpr{
+		List: buildItems(tokens, opener, lexer.RPAREN, "function literal"),
+	}
+
+	fe := FnExpr{Body: body}
+	if err := collectFnArgs(body, &fe); err != nil {
+		return nil, tokens.newError(opener.Start, "%s", err)
+	}
+
+	return fe, nil
+}
+
+// collectFnArgs walks the expr and updates the arity of the function based
+// on the argument symbols used.
+func collectFnArgs(expr Expr, fe *FnExpr) error {
+	switch e := expr.(type) {
+	case SymbolExpr:
+		switch {
+		case e.Symbol == "%":
+			if fe.Arity < 1 {
+				fe.Arity = 1
+			}
+
+		case e.Symbol == "%&":
+			fe.Variadic = true
+
+		case strings.HasPrefix(e.Symbol, "%"):
+			n, err := strconv.Atoi(e.Symbol[1:])
+			if err != nil {
+				break
+			} else if n < 1 || e.Symbol[1] == '+' {
+				return fmt.Errorf("invalid argument %s, arguments are numbered from %%1", e.Symbol)
+			}
+
+			if n > fe.Arity {
+				fe.Arity = n
+			}
+		}
+
+	case FnExpr:
+		return errors.New("nested #() forms are not allowed")
+
+	case ListExpr:
+		return collectFnArgsAll(e.List, fe)
+
+	case VectorExpr:
+		return collectFnArgsAll(e.List, fe)
+
+	case SetExpr:
+		return collectFnArgsAll(e.List, fe)
+
+	case MapExpr:
+		if err := collectFnArgsAll(e.Keys, fe); err != nil {
+			return err
+		}
+		return collectFnArgsAll(e.Values, fe)
+	}
+
+	return nil
+}
+
+func collectFnArgsAll(exprs []Expr, fe *FnExpr) error {
+	for _, expr := range exprs {
+		if err := collectFnArgs(expr, fe); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package parser
+
+import "github.com/spy16/parens/value"
+
+// FormExpr returns the Expr for evaluating a form read as data (e.g. by
+// ReadData or quoting). Lists become calls, symbols are resolved in the
+// scope, (quote form) lists become quoted forms and collections evaluate
+// their items. Exprs are returned as is and any other value evaluates to
+// itself.
+func FormExpr(form interface{}) Expr {
+	switch f := form.(type) {
+	case Expr:
+		return f
+
+	case value.Symbol:
+		return SymbolExpr{Symbol: f.String()}
+
+	case *value.List:
+		if f.Count() == 2 && f.First() == quoteSymbol {
+			return QuoteExpr{expr: FormExpr(f.Rest().First())}
+		}
+		return ListExpr{List: formExprs(f.Slice())}
+
+	case *value.Vector:
+		return VectorExpr{List: formExprs(f.Slice())}
+
+	case *value.Set:
+		return SetExpr{List: formExprs(f.Items())}
+
+	case *value.Map:
+		me := MapExpr{}
+		f.Range(func(key, val interface{}) bool {
+			me.Keys = append(me.Keys, FormExpr(key))
+			me.Values = append(me.Values, FormExpr(val))
+			return true
+		})
+		return me
+	}
+
+	return constExpr{val: form}
+}
+
+func formExprs(forms []interface{}) []Expr {
+	exprs := make([]Expr, len(forms))
+	for i, form := range forms {
+		exprs[i] = FormExpr(form)
+	}
+	return exprs
+}
+
+// constExpr evaluates to the value it holds.
+type constExpr struct {
+	val interface{}
+}
+
+func (ce constExpr) Eval(scope Scope) (interface{}, error) {
+	return ce.val, nil
+}
+
+func (ce constExpr) String() string {
+	return Repr(ce.val)
+}
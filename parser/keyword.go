@@ -1,15 +1,65 @@
 package parser
 
-// KeywordExpr represents a keyword literal.
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/spy16/parens/lexer"
+	"github.com/spy16/parens/value"
+)
+
+func newKeywordExpr(token *lexer.Token) KeywordExpr {
+	return KeywordExpr{
+		Keyword: token.Value,
+		Value:   value.ParseKeyword(token.Value),
+	}
+}
+
+// KeywordExpr represents a keyword literal. The value.Keyword is created
+// while parsing.
 type KeywordExpr struct {
 	Keyword string
+	Value   value.Keyword
 }
 
-// Eval returns the keyword itself.
+// Eval returns the interned value.Keyword.
 func (ke KeywordExpr) Eval(scope Scope) (interface{}, error) {
-	return ke.Keyword, nil
+	return ke.Value, nil
 }
 
 func (ke KeywordExpr) String() string {
 	return ke.Keyword
 }
+
+// invokeKeyword looks up the keyword in the map passed as the first
+// argument (e.g. (:name person)). The second argument, if given, is the
+// default value returned when the map does not contain the keyword.
+func invokeKeyword(kw value.Keyword, args []interface{}) (interface{}, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, fmt.Errorf("keyword lookup requires 1 or 2 arguments, got %d", len(args))
+	}
+
+	var def interface{}
+	if len(args) == 2 {
+		def = args[1]
+	}
+
+	switch m := args[0].(type) {
+	case *value.Map:
+		if val, found := m.Get(kw); found {
+			return val, nil
+		}
+
+	case nil:
+
+	default:
+		rv := reflect.ValueOf(m)
+		if rv.Kind() == reflect.Map && reflect.TypeOf(kw).AssignableTo(rv.Type().Key()) {
+			if val := rv.MapIndex(reflect.ValueOf(kw)); val.IsValid() {
+				return val.Interface(), nil
+			}
+		}
+	}
+
+	return def, nil
+}
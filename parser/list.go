@@ -6,6 +6,7 @@ import (
 
 	"github.com/spy16/parens/lexer"
 	"github.com/spy16/parens/reflection"
+	"github.com/spy16/parens/value"
 )
 
 // MacroFunc will receive un-evaluated list of s-expressions and the
@@ -18,16 +19,51 @@ type MacroFunc func(scope Scope, name string, exprs []Expr) (interface{}, error)
 // Eval is an example of a ScopedFunc.
 type ScopedFunc func(scope Scope, vals ...interface{}) (interface{}, error)
 
+// NativeFunc is the signature of Go functions that can be called by the
+// evaluator directly without going through reflection.
+type NativeFunc func(args ...interface{}) (interface{}, error)
+
+// Invokable can be implemented by any value that needs to be callable from
+// LISP without going through reflection.
+type Invokable interface {
+	Invoke(scope Scope, args ...interface{}) (interface{}, error)
+}
+
+// Call invokes fn with the given arguments. ScopedFunc, NativeFunc and
+// Invokable values are called directly, keywords look themselves up in the
+// map argument and any other Go function is called through reflection.
+func Call(scope Scope, fn interface{}, args ...interface{}) (interface{}, error) {
+	switch f := fn.(type) {
+	case ScopedFunc:
+		return f(scope, args...)
+
+	case NativeFunc:
+		return f(args...)
+
+	case func(args ...interface{}) (interface{}, error):
+		return f(args...)
+
+	case Invokable:
+		return f.Invoke(scope, args...)
+
+	case value.Keyword:
+		return invokeKeyword(f, args)
+
+	default:
+		return reflection.Call(fn, args...)
+	}
+}
+
 // ListExpr represents a list (i.e., a function call) expression.
 type ListExpr struct {
 	List []Expr
 }
 
 // Eval evaluates each s-exp in the list and then evaluates the list itself
-// as an s-exp.
+// as an s-exp. Empty list evaluates to an empty *value.List.
 func (le ListExpr) Eval(scope Scope) (interface{}, error) {
 	if len(le.List) == 0 {
-		return le.List, nil
+		return value.NewList(), nil
 	}
 
 	val, err := le.List[0].Eval(scope)
@@ -43,20 +79,16 @@ func (le ListExpr) Eval(scope Scope) (interface{}, error) {
 		return macroFn(scope, name, le.List[1:])
 	}
 
-	args := []interface{}{}
+	args := make([]interface{}, len(le.List)-1)
 	for i := 1; i < len(le.List); i++ {
 		arg, err := le.List[i].Eval(scope)
 		if err != nil {
 			return nil, err
 		}
-		args = append(args, arg)
-	}
-
-	if scopedFn, ok := val.(ScopedFunc); ok {
-		return scopedFn(scope, args...)
+		args[i-1] = arg
 	}
 
-	return reflection.Call(val, args...)
+	return Call(scope, val, args...)
 }
 
 func (le ListExpr) String() string {
@@ -68,27 +100,8 @@ func (le ListExpr) String() string {
 	return fmt.Sprintf("(%s)", strings.Join(reprs, " "))
 }
 
-func buildListExpr(tokens *tokenQueue) (Expr, error) {
-	le := ListExpr{}
-
-	for {
-		next := tokens.Token(0)
-		if next == nil {
-			return nil, ErrEOF
-		}
-
-		if next.Type == lexer.RPAREN {
-			break
-		}
-
-		exp, err := buildExpr(tokens)
-		if err != nil {
-			return nil, err
-		}
-
-		le.List = append(le.List, exp)
-	}
-
-	tokens.Pop()
-	return le, nil
+func buildListExpr(opener *lexer.Token, tokens *tokenQueue) (Expr, error) {
+	return ListExpr{
+		List: buildItems(tokens, opener, lexer.RPAREN, "list"),
+	}, nil
 }
@@ -2,49 +2,104 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/spy16/parens/lexer"
+	"github.com/spy16/parens/value"
 )
 
-// MapExpr represents a map literal expression.
+// MapExpr represents a map literal expression. Keys and values are in the
+// order they appear in the source.
 type MapExpr struct {
-	hashMap map[string]Expr
+	Keys   []Expr
+	Values []Expr
 }
 
-// Eval evaluates a map literal expression into map[string]interface{}
+// Eval evaluates the keys and values in the source order into a *value.Map.
+// Returns error if two keys evaluate to the same value.
 func (me MapExpr) Eval(scope Scope) (interface{}, error) {
-	m := map[string]interface{}{}
-	for key, valExpr := range me.hashMap {
-		val, err := valExpr.Eval(scope)
+	kvs := make([]interface{}, 0, 2*len(me.Keys))
+	for i, keyExpr := range me.Keys {
+		key, err := keyExpr.Eval(scope)
 		if err != nil {
 			return nil, err
 		}
 
-		m[key] = val
+		val, err := me.Values[i].Eval(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		kvs = append(kvs, key, val)
 	}
-	return m, nil
+
+	return newMap(kvs)
+}
+
+func (me MapExpr) String() string {
+	strs := []string{}
+	for i, key := range me.Keys {
+		strs = append(strs, fmt.Sprintf("%v %v", key, me.Values[i]))
+	}
+
+	return fmt.Sprintf("{%s}", strings.Join(strs, " "))
 }
 
-func buildMapExpr(queue *tokenQueue) (Expr, error) {
+func buildMapExpr(opener *lexer.Token, tokens *tokenQueue) (Expr, error) {
+	items := buildItems(tokens, opener, lexer.RDICT, "map")
+	if len(items)%2 != 0 {
+		return nil, tokens.newError(opener.Start, "map literal must contain an even number of forms")
+	}
+
 	me := MapExpr{}
-	me.hashMap = map[string]Expr{}
+	seen := map[string]bool{}
+	for i := 0; i < len(items); i += 2 {
+		if key, ok := literalKey(items[i]); ok {
+			if seen[key] {
+				return nil, tokens.newError(opener.Start, "duplicate key '%v' in map literal", items[i])
+			}
+			seen[key] = true
+		}
 
-	for {
-		key := queue.Pop()
-		if key == nil || key.Type == lexer.RDICT {
-			break
+		me.Keys = append(me.Keys, items[i])
+		me.Values = append(me.Values, items[i+1])
+	}
+
+	return me, nil
+}
+
+// literalKey returns the canonical form of the key if it is a literal
+// (e.g. keyword, string, number) that can be compared while parsing.
+func literalKey(expr Expr) (string, bool) {
+	switch expr.(type) {
+	case KeywordExpr, StringExpr, NumberExpr, CharacterExpr:
+		val, err := exprData(expr)
+		if err != nil {
+			return "", false
 		}
+		return Repr(val), true
+
+	case SymbolExpr:
+		// same symbol evaluates to the same value.
+		return fmt.Sprint(expr), true
+	}
 
-		if key.Type != lexer.KEYWORD {
-			return nil, fmt.Errorf("expecting keyword, not '%s'", key.Type)
+	return "", false
+}
+
+// newMap creates a map from the alternating keys and values. Returns error
+// if a key appears more than once.
+func newMap(kvs []interface{}) (*value.Map, error) {
+	m, _ := value.NewMap()
+	for i := 0; i < len(kvs); i += 2 {
+		if _, found := m.Get(kvs[i]); found {
+			return nil, fmt.Errorf("duplicate map key '%s'", Repr(kvs[i]))
 		}
 
-		val, err := buildExpr(queue)
-		if err != nil {
+		var err error
+		if m, err = m.Assoc(kvs[i], kvs[i+1]); err != nil {
 			return nil, err
 		}
-		me.hashMap[key.Value] = val
 	}
-
-	return me, nil
+	return m, nil
 }
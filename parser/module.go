@@ -34,24 +34,22 @@ func (me ModuleExpr) String() string {
 	return strings.Join(strs, "\n")
 }
 
-func buildModuleExpr(name string, queue *tokenQueue) (Expr, error) {
-	me := ModuleExpr{}
-	me.Name = name
-
-	for {
-		expr, err := buildExpr(queue)
-		if err != nil {
-			if err == ErrEOF {
-				break
-			}
-			return nil, err
-		}
+func buildModuleExpr(name string, queue *tokenQueue) Expr {
+	return ModuleExpr{
+		Name:  name,
+		Exprs: buildForms(queue),
+	}
+}
 
-		if expr != nil {
-			me.Exprs = append(me.Exprs, expr)
+// buildForms builds all the remaining forms in the queue. Forms with errors
+// and forms discarded using '#_' are not included.
+func buildForms(queue *tokenQueue) []Expr {
+	var exprs []Expr
+	for queue.Token(0) != nil {
+		if expr := buildRecover(queue); expr != nil {
+			exprs = append(exprs, expr)
 		}
-
 	}
 
-	return me, nil
+	return exprs
 }
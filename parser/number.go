@@ -1,19 +1,58 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/spy16/parens/lexer"
 )
 
-func newNumberExpr(token *lexer.Token) NumberExpr {
+func newNumberExpr(token *lexer.Token) (NumberExpr, error) {
+	num, err := parseNumber(token.Value)
+	if err != nil {
+		return NumberExpr{}, err
+	}
+
 	return NumberExpr{
 		NumStr: token.Value,
+		Number: num,
+	}, nil
+}
+
+// symbolicValues are the floats without a numeric literal form. They are
+// written as '##' followed by the name (e.g. ##Inf).
+var symbolicValues = map[string]float64{
+	"Inf":  math.Inf(1),
+	"-Inf": math.Inf(-1),
+	"NaN":  math.NaN(),
+}
+
+// buildSymbolicExpr reads the name of the symbolic value following '##'.
+func buildSymbolicExpr(hash *lexer.Token, tokens *tokenQueue) (Expr, error) {
+	name := tokens.Token(0)
+	if name == nil || name.Type != lexer.SYMBOL || name.Start != hash.Start+len(hash.Value) {
+		return nil, errors.New("expecting Inf, -Inf or NaN after '##'")
+	}
+	tokens.Pop()
+
+	num, found := symbolicValues[name.Value]
+	if !found {
+		return nil, tokens.newError(hash.Start, "unknown symbolic value '##%s'", name.Value)
 	}
+
+	return NumberExpr{
+		NumStr: hash.Value + name.Value,
+		Number: num,
+	}, nil
 }
 
-// NumberExpr represents number s-expression.
+// NumberExpr represents number s-expression. Integers evaluate to int64,
+// floats to float64, integers too big for int64 (or with 'N' suffix) to
+// *big.Int and ratios (e.g. 3/4) to *big.Rat.
 type NumberExpr struct {
 	NumStr string
 	Number interface{}
@@ -22,7 +61,7 @@ type NumberExpr struct {
 // Eval for a number returns itself.
 func (ne NumberExpr) Eval(scope Scope) (interface{}, error) {
 	if ne.Number == nil {
-		num, err := strconv.ParseFloat(ne.NumStr, 64)
+		num, err := parseNumber(ne.NumStr)
 		if err != nil {
 			return nil, err
 		}
@@ -36,3 +75,78 @@ func (ne NumberExpr) Eval(scope Scope) (interface{}, error) {
 func (ne NumberExpr) String() string {
 	return fmt.Sprint(ne.NumStr)
 }
+
+// parseNumber parses all the number literal forms supported by the lexer.
+func parseNumber(numStr string) (interface{}, error) {
+	str := strings.Replace(numStr, "_", "", -1)
+
+	sign := ""
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		sign, str = str[:1], str[1:]
+	}
+
+	base := 10
+	if len(str) > 2 && str[0] == '0' {
+		switch str[1] {
+		case 'x', 'X':
+			base = 16
+		case 'o', 'O':
+			base = 8
+		case 'b', 'B':
+			base = 2
+		}
+
+		if base != 10 {
+			str = str[2:]
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(str, "N"):
+		return parseBigInt(numStr, sign+str[:len(str)-1], base)
+
+	case base == 10 && strings.Contains(str, "/"):
+		rat, ok := new(big.Rat).SetString(sign + str)
+		if !ok {
+			return nil, fmt.Errorf("invalid ratio '%s'", numStr)
+		}
+
+		if rat.IsInt() {
+			return normalizeBigInt(rat.Num()), nil
+		}
+		return rat, nil
+
+	case base == 10 && strings.ContainsAny(str, ".eE"):
+		f, err := strconv.ParseFloat(sign+str, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float '%s'", numStr)
+		}
+		return f, nil
+	}
+
+	i, err := strconv.ParseInt(sign+str, base, 64)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return parseBigInt(numStr, sign+str, base)
+		}
+		return nil, fmt.Errorf("invalid integer '%s'", numStr)
+	}
+
+	return i, nil
+}
+
+func parseBigInt(numStr, str string, base int) (*big.Int, error) {
+	bi, ok := new(big.Int).SetString(str, base)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer '%s'", numStr)
+	}
+	return bi, nil
+}
+
+// normalizeBigInt returns the value as int64 if it fits, as is otherwise.
+func normalizeBigInt(bi *big.Int) interface{} {
+	if bi.IsInt64() {
+		return bi.Int64()
+	}
+	return bi
+}
@@ -10,15 +10,55 @@ import (
 // ErrEOF is returned when the parser has consumed all tokens.
 var ErrEOF = errors.New("end of file")
 
-// Parse tokenizes and parses the src to build an AST.
+// Parse tokenizes and parses the src to build an AST using the
+// DefaultMacros and DefaultTags. Parsing continues after syntax errors to find as many
+// errors as possible. If the source has syntax errors, they are returned
+// as ErrorList.
 func Parse(name string, src string) (Expr, error) {
-	tokens, err := lexer.New(src).Tokens()
-	if err != nil {
+	return defaultParser.Parse(name, src)
+}
+
+// Parser parses source code using its own registries of reader macros and
+// tag readers. The zero value parses only the built-in syntax and tags.
+type Parser struct {
+	// Macros are the reader macros and dispatch macros available to the
+	// source being parsed. nil disables custom macros.
+	Macros *Macros
+
+	// Tags are the readers for tagged literals. nil provides only the
+	// built-in tags.
+	Tags *Tags
+}
+
+var defaultParser = &Parser{Macros: defaultMacros, Tags: defaultTags}
+
+// NewParser creates a parser with an empty macro registry and a tag
+// registry with only the built-in tags. If inheritDefaults is true, the
+// registries start as copies of the DefaultMacros and DefaultTags instead.
+func NewParser(inheritDefaults bool) *Parser {
+	if inheritDefaults {
+		return &Parser{Macros: defaultMacros.Clone(), Tags: defaultTags.Clone()}
+	}
+	return &Parser{Macros: NewMacros(), Tags: NewTags()}
+}
+
+// Parse tokenizes and parses the src to build an AST. See Parse.
+func (p *Parser) Parse(name string, src string) (Expr, error) {
+	return p.parse(name, src, false)
+}
+
+// parse builds the AST. If skipTags is true, tag readers are not called
+// and tagged literals are kept as TaggedLiteral values.
+func (p *Parser) parse(name, src string, skipTags bool) (Expr, error) {
+	queue := newTokenQueue(name, src, Position{Line: 1, Column: 1}, p.Macros, p.Tags)
+	queue.skipTags = skipTags
+
+	expr := buildModuleExpr(name, queue)
+	if err := queue.Err(); err != nil {
 		return nil, err
 	}
 
-	queue := &tokenQueue{tokens: tokens}
-	return buildModuleExpr(name, queue)
+	return expr, nil
 }
 
 // Expr represents an evaluatable expression.
@@ -35,47 +75,108 @@ type Scope interface {
 }
 
 func buildExpr(tokens *tokenQueue) (Expr, error) {
-	if len(tokens.tokens) == 0 {
+	token := tokens.Token(0)
+	if token == nil {
 		return nil, ErrEOF
+	} else if isClosing(token.Type) {
+		// closing token is left for the enclosing form to handle.
+		return nil, tokens.newError(token.Start, "unexpected '%s'", token.Value)
 	}
-
-	token := tokens.Pop()
+	tokens.Pop()
 
 	switch token.Type {
 	case lexer.LPAREN:
-		return buildListExpr(tokens)
+		return buildListExpr(token, tokens)
 
 	case lexer.NUMBER:
-		return newNumberExpr(token), nil
+		return newNumberExpr(token)
 
 	case lexer.STRING:
-		return newStringExpr(token), nil
+		return newStringExpr(token)
+
+	case lexer.CHARACTER:
+		return newCharacterExpr(token)
 
 	case lexer.SYMBOL:
 		return newSymbolExpr(token), nil
 
 	case lexer.LVECT:
-		return buildVectorExpr(tokens)
+		return buildVectorExpr(token, tokens)
 
 	case lexer.LDICT:
-		return buildMapExpr(tokens)
+		return buildMapExpr(token, tokens)
 
 	case lexer.KEYWORD:
-		return KeywordExpr{
-			Keyword: token.Value,
-		}, nil
+		return newKeywordExpr(token), nil
+
+	case lexer.REGEX:
+		return newRegexExpr(token)
+
+	case lexer.DISPATCH:
+		return buildDispatchExpr(token, tokens)
+
+	case lexer.MACRO:
+		return buildMacroExpr(token, tokens)
 
 	case lexer.QUOTE:
-		expr, err := buildExpr(tokens)
+		expr, err := buildForm(tokens)
 		if err != nil {
 			return nil, err
 		}
 		return QuoteExpr{expr: expr}, nil
 
-	case lexer.RPAREN, lexer.RVECT, lexer.RDICT:
-		return nil, ErrEOF
-
 	default:
 		return nil, fmt.Errorf("unknown token type: %s", (token.Type))
 	}
 }
+
+// buildRecover builds the next form. If the form has errors, they are
+// recorded and nil is returned. At least one token is consumed so that
+// parsing can continue with the next form.
+func buildRecover(tokens *tokenQueue) Expr {
+	next := tokens.Token(0)
+	start := tokens.pos
+
+	expr, err := buildExpr(tokens)
+	if err != nil {
+		tokens.record(err, next)
+		if tokens.pos == start {
+			tokens.Pop()
+		}
+		return nil
+	}
+
+	return expr
+}
+
+// buildItems builds the forms till the closing token of the collection
+// opened by the opener. Unexpected closing tokens are reported and skipped.
+// If the closing token is missing, the collection is reported as unclosed.
+func buildItems(tokens *tokenQueue, opener *lexer.Token, closer lexer.TokenType, kind string) []Expr {
+	var items []Expr
+
+	for {
+		next := tokens.Token(0)
+		if next == nil {
+			tokens.errorAt(opener.Start, "unclosed %s", kind)
+			return items
+		}
+
+		if next.Type == closer {
+			tokens.Pop()
+			return items
+		} else if isClosing(next.Type) {
+			tokens.errorAt(next.Start, "unexpected '%s' in %s", next.Value, kind)
+			tokens.Pop()
+			continue
+		}
+
+		if expr := buildRecover(tokens); expr != nil {
+			items = append(items, expr)
+		}
+	}
+}
+
+func isClosing(tokenType lexer.TokenType) bool {
+	return tokenType == lexer.RPAREN || tokenType == lexer.RVECT || tokenType == lexer.RDICT
+}
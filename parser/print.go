@@ -0,0 +1,245 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spy16/parens/lexer"
+	"github.com/spy16/parens/value"
+)
+
+// Repr returns the readable representation of the value, i.e., reading the
+// returned string using the parser results in a value equal to v. Values
+// that have no literal syntax (e.g. functions, channels) are printed in a
+// descriptive form that cannot be read back.
+func Repr(v interface{}) string {
+	var sb strings.Builder
+	writeRepr(&sb, v)
+	return sb.String()
+}
+
+func writeRepr(sb *strings.Builder, v interface{}) {
+	switch val := v.(type) {
+	case nil:
+		sb.WriteString("nil")
+
+	case bool:
+		sb.WriteString(strconv.FormatBool(val))
+
+	case string:
+		sb.WriteString(strconv.Quote(val))
+
+	case rune:
+		sb.WriteString(reprChar(val))
+
+	case int64:
+		sb.WriteString(strconv.FormatInt(val, 10))
+
+	case float64:
+		sb.WriteString(reprFloat(val))
+
+	case *big.Int:
+		if val == nil {
+			sb.WriteString("nil")
+			return
+		}
+		sb.WriteString(val.String())
+		sb.WriteString("N")
+
+	case *big.Rat:
+		if val == nil {
+			sb.WriteString("nil")
+			return
+		}
+		sb.WriteString(val.String())
+
+	case *regexp.Regexp:
+		sb.WriteString("#\"")
+		sb.WriteString(val.String())
+		sb.WriteString("\"")
+
+	case time.Time:
+		sb.WriteString("#inst ")
+		sb.WriteString(strconv.Quote(val.Format(time.RFC3339Nano)))
+
+	case UUID:
+		sb.WriteString("#uuid ")
+		sb.WriteString(strconv.Quote(val.String()))
+
+	case value.Keyword, value.Symbol:
+		sb.WriteString(fmt.Sprint(val))
+
+	case TaggedLiteral:
+		sb.WriteString("#")
+		sb.WriteString(val.Tag)
+		sb.WriteString(" ")
+		writeRepr(sb, val.Form)
+
+	case []interface{}:
+		writeSeq(sb, "[", "]", len(val), func(i int) interface{} { return val[i] })
+
+	case value.Seq:
+		// lists and lazy sequences print the same way.
+		items, err := value.SeqItems(val)
+		if err != nil {
+			fmt.Fprintf(sb, "<error: %v>", err)
+			return
+		}
+		writeSeq(sb, "(", ")", len(items), func(i int) interface{} { return items[i] })
+
+	case *value.Vector:
+		writeSeq(sb, "[", "]", val.Count(), func(i int) interface{} {
+			item, _ := val.Nth(i)
+			return item
+		})
+
+	case *value.Set:
+		items := val.Items()
+		writeSeq(sb, "#{", "}", len(items), func(i int) interface{} { return items[i] })
+
+	case *value.Map:
+		if val == nil {
+			sb.WriteString("nil")
+			return
+		}
+
+		sb.WriteString("{")
+		first := true
+		val.Range(func(key, v interface{}) bool {
+			if !first {
+				sb.WriteString(" ")
+			}
+			first = false
+
+			writeRepr(sb, key)
+			sb.WriteString(" ")
+			writeRepr(sb, v)
+			return true
+		})
+		sb.WriteString("}")
+
+	case Expr:
+		// quoted forms evaluate to the expressions which print in the
+		// source form.
+		sb.WriteString(fmt.Sprint(val))
+
+	default:
+		writeReflected(sb, reflect.ValueOf(v))
+	}
+}
+
+// writeReflected prints the Go values which do not have a dedicated case
+// in writeRepr (e.g. int, []string, map[string]int).
+func writeReflected(sb *strings.Builder, rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		sb.WriteString(strconv.FormatInt(rv.Int(), 10))
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > math.MaxInt64 {
+			sb.WriteString(strconv.FormatUint(rv.Uint(), 10))
+			sb.WriteString("N")
+			return
+		}
+		sb.WriteString(strconv.FormatUint(rv.Uint(), 10))
+
+	case reflect.Float32:
+		sb.WriteString(reprFloat(rv.Float()))
+
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		writeSeq(sb, "[", "]", rv.Len(), func(i int) interface{} { return rv.Index(i).Interface() })
+
+	case reflect.Map:
+		if rv.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+
+		entries := make([]string, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			var entry strings.Builder
+			writeRepr(&entry, iter.Key().Interface())
+			entry.WriteString(" ")
+			writeRepr(&entry, iter.Value().Interface())
+			entries = append(entries, entry.String())
+		}
+		sort.Strings(entries)
+
+		sb.WriteString("{")
+		sb.WriteString(strings.Join(entries, " "))
+		sb.WriteString("}")
+
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		if _, ok := rv.Interface().(fmt.Stringer); ok {
+			sb.WriteString(fmt.Sprint(rv.Interface()))
+			return
+		}
+		writeReflected(sb, rv.Elem())
+
+	case reflect.Func:
+		fmt.Fprintf(sb, "<function: %s>", rv.Type())
+
+	case reflect.Chan:
+		fmt.Fprintf(sb, "<%s>", rv.Type())
+
+	default:
+		fmt.Fprintf(sb, "%v", rv.Interface())
+	}
+}
+
+func writeSeq(sb *strings.Builder, open, close string, n int, item func(i int) interface{}) {
+	sb.WriteString(open)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		writeRepr(sb, item(i))
+	}
+	sb.WriteString(close)
+}
+
+// reprFloat formats the float so that it is read back as a float (e.g.
+// 1.0 instead of 1).
+func reprFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "##Inf"
+	case math.IsInf(f, -1):
+		return "##-Inf"
+	case math.IsNaN(f):
+		return "##NaN"
+	}
+
+	str := strconv.FormatFloat(f, 'g', -1, 64)
+	if strings.ContainsAny(str, ".e") {
+		return str
+	}
+	return str + ".0"
+}
+
+func reprChar(ru rune) string {
+	if name, found := lexer.CharacterName(ru); found {
+		return "\\" + name
+	}
+
+	if strconv.IsPrint(ru) || ru > 0xFFFF {
+		return "\\" + string(ru)
+	}
+	return fmt.Sprintf("\\u%04x", ru)
+}
@@ -2,16 +2,22 @@ package parser
 
 import (
 	"fmt"
+
+	"github.com/spy16/parens/value"
 )
 
+var quoteSymbol = value.NewSymbol("", "quote")
+
 // QuoteExpr implements the quote-literal form.
 type QuoteExpr struct {
 	expr Expr
 }
 
-// Eval returns the expression itself without evaluating it.
+// Eval returns the expression as data without evaluating it (e.g. '(+ 1 2)
+// results in a *value.List of a value.Symbol and two numbers). See ReadData
+// for the values different forms result in.
 func (qe QuoteExpr) Eval(scope Scope) (interface{}, error) {
-	return qe.expr, nil
+	return exprData(qe.expr)
 }
 
 // UnquoteEval unquotes and evaluates the underlying expression.
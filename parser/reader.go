@@ -0,0 +1,284 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spy16/parens/lexer"
+)
+
+// ErrUnexpectedEOF is returned by Reader when the source ends in the middle
+// of a form.
+var ErrUnexpectedEOF = errors.New("unexpected end of file")
+
+// NewReader initializes a Reader that reads forms from r using the
+// DefaultMacros and DefaultTags. name is used as the name of the source (e.g. file name).
+func NewReader(name string, r io.Reader) *Reader {
+	return defaultParser.NewReader(name, r)
+}
+
+// NewReader initializes a Reader that reads forms from r using the macros
+// and tags of the parser. See NewReader.
+func (p *Parser) NewReader(name string, r io.Reader) *Reader {
+	return &Reader{
+		Name:   name,
+		rd:     r,
+		pos:    Position{Line: 1, Column: 1},
+		macros: p.Macros,
+		tags:   p.Tags,
+	}
+}
+
+// Reader reads top-level forms one at a time from an io.Reader. Only the
+// source of the form being read (and at most one chunk of input following
+// it) is held in memory which allows processing large or never-ending
+// sources (e.g. piped stdin) incrementally.
+//
+// Reader macros and dispatch macros are expected to consume exactly one form following them.
+type Reader struct {
+	Name string
+
+	rd      io.Reader
+	eof     bool
+	buf     []byte
+	scan    formScan
+	pending []Expr
+	macros  *Macros
+	tags    *Tags
+
+	// position of the next form to be read.
+	pos Position
+}
+
+// formScan is the state of identifying the boundary of the form at the
+// beginning of the buffer.
+type formScan struct {
+	// offset in the buffer till which the tokens have been scanned.
+	offset int
+
+	// closing brackets expected for the currently open forms.
+	closers []lexer.TokenType
+
+	// prefixes waiting for a top-level form. true represents '#_' which
+	// discards the form instead of completing it.
+	prefixes []bool
+
+	started bool
+	tagged  bool
+}
+
+// Read reads and parses the next top-level form. Forms discarded using '#_'
+// are skipped. Returns io.EOF when there are no more forms.
+func (rd *Reader) Read() (Expr, error) {
+	for len(rd.pending) == 0 {
+		base := rd.pos
+		src, err := rd.ReadSource()
+		if err != nil {
+			return nil, err
+		}
+
+		queue := newTokenQueue(rd.Name, src, base, rd.macros, rd.tags)
+		rd.pending = buildForms(queue)
+		if err := queue.Err(); err != nil {
+			rd.pending = nil
+			return nil, err
+		}
+	}
+
+	expr := rd.pending[0]
+	rd.pending = rd.pending[1:]
+	return expr, nil
+}
+
+// ReadSource reads the source of the next top-level form without parsing
+// it. Whitespaces and comments preceding the form are included. Returns
+// io.EOF when there are no more forms.
+func (rd *Reader) ReadSource() (string, error) {
+	for {
+		end, err := rd.scanForm()
+		if err != nil {
+			return "", err
+		} else if end > 0 {
+			src := string(rd.buf[:end])
+			rd.consume(end)
+			return src, nil
+		}
+
+		if rd.eof {
+			started := rd.scan.started
+			rd.consume(len(rd.buf))
+			if started {
+				return "", ErrUnexpectedEOF
+			}
+			return "", io.EOF
+		}
+
+		if err := rd.fill(); err != nil {
+			// the partially read form is discarded.
+			rd.consume(len(rd.buf))
+			return "", err
+		}
+	}
+}
+
+// scanForm lexes the buffered input following the tokens scanned so far
+// and returns the offset at which the top-level form at the beginning of
+// the buffer ends. Returns 0 if more input is required to find the end.
+func (rd *Reader) scanForm() (int, error) {
+	base := rd.scan.offset
+	lex := lexer.New(string(rd.buf[base:]))
+	lex.MacroChars = rd.macros.chars()
+
+	for {
+		token, err := lex.Next()
+		if err == lexer.ErrEOF {
+			return 0, nil
+		}
+
+		start, end := base+lex.Offset(), base+lex.End()
+		if end == len(rd.buf) && !rd.eof && (err != nil || !isCloser(token.Type)) {
+			// the token might continue in the input not read yet.
+			return 0, nil
+		}
+		rd.scan.offset = end
+
+		if err == lexer.ErrUnterminatedString {
+			rd.consume(end)
+			return 0, ErrUnexpectedEOF
+		} else if err != nil {
+			// invalid tokens are left for the parser to report.
+			token = &lexer.Token{Type: lexer.SYMBOL}
+		}
+
+		complete, err := rd.scan.next(*token)
+		if err != nil {
+			pos := advance(rd.pos, rd.buf[:start])
+			pos.File = rd.Name
+			rd.consume(end)
+			return 0, &SyntaxError{Pos: pos, Msg: err.Error()}
+		} else if complete {
+			return end, nil
+		}
+	}
+}
+
+// next updates the state with the token and returns true if the token
+// completes a top-level form.
+func (fs *formScan) next(token lexer.Token) (bool, error) {
+	if token.IsTrivia() {
+		return false, nil
+	}
+	fs.started = true
+
+	if fs.tagged {
+		// tag of a tagged literal. literal is the form following it.
+		fs.tagged = false
+		return false, nil
+	}
+
+	switch token.Type {
+	case lexer.LPAREN:
+		fs.closers = append(fs.closers, lexer.RPAREN)
+		return false, nil
+
+	case lexer.LVECT:
+		fs.closers = append(fs.closers, lexer.RVECT)
+		return false, nil
+
+	case lexer.LDICT:
+		fs.closers = append(fs.closers, lexer.RDICT)
+		return false, nil
+
+	case lexer.RPAREN, lexer.RVECT, lexer.RDICT:
+		if len(fs.closers) == 0 {
+			return false, fmt.Errorf("unexpected '%s'", token.Value)
+		}
+
+		// mismatched closing brackets are left for the parser to report.
+		if fs.closers[len(fs.closers)-1] == token.Type {
+			fs.closers = fs.closers[:len(fs.closers)-1]
+		}
+
+	case lexer.DISPATCH:
+		switch token.Value {
+		case "#(":
+			fs.closers = append(fs.closers, lexer.RPAREN)
+			return false, nil
+
+		case "#{":
+			fs.closers = append(fs.closers, lexer.RDICT)
+			return false, nil
+
+		case "#":
+			fs.tagged = true
+		}
+		fs.prefix(token.Value == "#_")
+		return false, nil
+
+	case lexer.QUOTE, lexer.MACRO:
+		fs.prefix(false)
+		return false, nil
+	}
+
+	if len(fs.closers) > 0 {
+		return false, nil
+	}
+
+	// a top-level form is complete. it completes the prefixes waiting for
+	// it unless it gets discarded.
+	complete := true
+	for complete && len(fs.prefixes) > 0 {
+		complete = !fs.prefixes[len(fs.prefixes)-1]
+		fs.prefixes = fs.prefixes[:len(fs.prefixes)-1]
+	}
+
+	if !complete && len(fs.prefixes) == 0 {
+		// only discarded forms so far.
+		fs.started = false
+	}
+	return complete, nil
+}
+
+func (fs *formScan) prefix(discard bool) {
+	if len(fs.closers) == 0 {
+		fs.prefixes = append(fs.prefixes, discard)
+	}
+}
+
+// fill reads the next chunk of input into the buffer.
+func (rd *Reader) fill() error {
+	var chunk [4096]byte
+	n, err := rd.rd.Read(chunk[:])
+	rd.buf = append(rd.buf, chunk[:n]...)
+	if err == io.EOF {
+		rd.eof = true
+		return nil
+	}
+	return err
+}
+
+// consume removes n bytes from the beginning of the buffer and resets the
+// scan state.
+func (rd *Reader) consume(n int) {
+	rd.pos = advance(rd.pos, rd.buf[:n])
+	rd.buf = append(rd.buf[:0], rd.buf[n:]...)
+	rd.scan = formScan{}
+}
+
+// advance returns the position after the text starting at pos.
+func advance(pos Position, text []byte) Position {
+	for _, ru := range string(text) {
+		if ru == '\n' {
+			pos.Line++
+			pos.Column = 1
+		} else {
+			pos.Column++
+		}
+	}
+	return pos
+}
+
+func isCloser(tt lexer.TokenType) bool {
+	return tt == lexer.RPAREN || tt == lexer.RVECT || tt == lexer.RDICT
+}
@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/spy16/parens/lexer"
+)
+
+func newRegexExpr(token *lexer.Token) (RegexExpr, error) {
+	pattern := token.Value[2 : len(token.Value)-1]
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return RegexExpr{}, fmt.Errorf("invalid regex literal %s: %s", token.Value, err)
+	}
+
+	return RegexExpr{
+		Pattern: pattern,
+		Regexp:  re,
+	}, nil
+}
+
+// RegexExpr represents a regular expression literal (e.g. #"\d+"). The
+// expression is compiled while parsing.
+type RegexExpr struct {
+	Pattern string
+	Regexp  *regexp.Regexp
+}
+
+// Eval returns the compiled *regexp.Regexp.
+func (re RegexExpr) Eval(scope Scope) (interface{}, error) {
+	return re.Regexp, nil
+}
+
+func (re RegexExpr) String() string {
+	return fmt.Sprintf("#\"%s\"", re.Pattern)
+}
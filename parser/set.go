@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spy16/parens/lexer"
+	"github.com/spy16/parens/value"
+)
+
+// SetExpr represents a set literal (e.g. #{1 2 3}).
+type SetExpr struct {
+	List []Expr
+}
+
+// Eval evaluates the items and creates a *value.Set. Returns error if an
+// item is not hashable or if two items are equal.
+func (se SetExpr) Eval(scope Scope) (interface{}, error) {
+	items, err := evalAll(scope, se.List)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSet(items)
+}
+
+func (se SetExpr) String() string {
+	strs := []string{}
+	for _, expr := range se.List {
+		strs = append(strs, fmt.Sprint(expr))
+	}
+
+	return fmt.Sprintf("#{%s}", strings.Join(strs, " "))
+}
+
+func buildSetExpr(opener *lexer.Token, tokens *tokenQueue) (Expr, error) {
+	return SetExpr{
+		List: buildItems(tokens, opener, lexer.RDICT, "set"),
+	}, nil
+}
+
+// newSet creates a set with the items. Returns error if an item is not
+// hashable or appears more than once.
+func newSet(items []interface{}) (*value.Set, error) {
+	set, _ := value.NewSet()
+	for _, item := range items {
+		if set.Contains(item) {
+			return nil, fmt.Errorf("duplicate set item '%s'", Repr(item))
+		}
+
+		var err error
+		if set, err = set.Conj(item); err != nil {
+			return nil, err
+		}
+	}
+
+	return set, nil
+}
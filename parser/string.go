@@ -1,44 +1,62 @@
 package parser
 
 import (
-	"strings"
+	"strconv"
+	"unicode/utf8"
 
 	"github.com/spy16/parens/lexer"
-
-	"github.com/spy16/parens/lexer/utfstrings"
 )
 
-func newStringExpr(token *lexer.Token) StringExpr {
+func newStringExpr(token *lexer.Token) (StringExpr, error) {
+	str, err := unquoteStr(token.Value)
+	if err != nil {
+		return StringExpr{}, err
+	}
+
 	return StringExpr{
 		value: token.Value,
-	}
+		str:   str,
+	}, nil
 }
 
-// StringExpr represents single and double quoted strings.
+// StringExpr represents double quoted and back quoted (raw) strings.
 type StringExpr struct {
 	value string
+	str   string
 }
 
 // Eval returns unquoted version of the STRING token.
 func (se StringExpr) Eval(_ Scope) (interface{}, error) {
-	return unquoteStr(se.value), nil
+	return se.str, nil
 }
 
 func (se StringExpr) String() string {
 	return se.value
 }
 
-func unquoteStr(str string) string {
-	sc := &utfstrings.Cursor{
-		String: str[1 : len(str)-1],
+// unquoteStr removes the quotes around the string literal. Escape sequences
+// in double-quoted strings are replaced with the characters they represent
+// while raw strings are returned as is.
+func unquoteStr(str string) (string, error) {
+	if str[0] == '`' {
+		return str[1 : len(str)-1], nil
 	}
 
-	final := sc.Build(func(cur *utfstrings.Cursor) {
-		if ru := cur.Next(); ru == '\\' && cur.Peek() == '"' {
-			return
+	str = str[1 : len(str)-1]
+	buf := make([]byte, 0, len(str))
+	for len(str) > 0 {
+		ru, multibyte, tail, err := strconv.UnquoteChar(str, '"')
+		if err != nil {
+			return "", err
 		}
-		cur.Backup()
-	})
+		str = tail
+
+		if ru < utf8.RuneSelf || !multibyte {
+			buf = append(buf, byte(ru))
+		} else {
+			buf = append(buf, string(ru)...)
+		}
+	}
 
-	return strings.Replace(final, "\\n", "\n", -1)
+	return string(buf), nil
 }
@@ -0,0 +1,244 @@
+package parser
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spy16/parens/lexer"
+)
+
+// TagReader converts the value of the form following a tag (e.g. the string
+// in '#inst "2018-11-03"') into the value the tagged literal represents.
+type TagReader func(form interface{}) (interface{}, error)
+
+// Tags is a registry of tag readers. Every Parser uses its own Tags so that
+// the tags registered by one user of the package do not change how the
+// source of others is read.
+type Tags struct {
+	mu      sync.RWMutex
+	readers map[string]TagReader
+}
+
+// NewTags creates a registry with only the built-in tags ('inst' and
+// 'uuid').
+func NewTags() *Tags {
+	return &Tags{readers: builtinTags()}
+}
+
+var defaultTags = NewTags()
+
+// DefaultTags returns the process-wide registry used by the package-level
+// Parse, ParseCST and NewReader functions. Prefer a Parser with its own
+// Tags when the process may have other users of the package.
+func DefaultTags() *Tags {
+	return defaultTags
+}
+
+// RegisterTag registers a tag reader in the DefaultTags. See
+// Tags.Register.
+func RegisterTag(tag string, reader TagReader) error {
+	return defaultTags.Register(tag, reader)
+}
+
+// Clone returns a copy of the registry. Registering or unregistering tags
+// in either of them does not affect the other.
+func (t *Tags) Clone() *Tags {
+	clone := &Tags{readers: map[string]TagReader{}}
+	if t == nil {
+		clone.readers = builtinTags()
+		return clone
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for tag, reader := range t.readers {
+		clone.readers[tag] = reader
+	}
+	return clone
+}
+
+// Register registers a reader for tagged literals with the given tag. Tag
+// must start with a letter (e.g. 'myapp/point'). Registering a tag that is
+// already registered replaces the existing reader.
+func (t *Tags) Register(tag string, reader TagReader) error {
+	ru, _ := utf8.DecodeRuneInString(tag)
+	if !unicode.IsLetter(ru) {
+		return fmt.Errorf("invalid tag '%s': must start with a letter", tag)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.readers[tag] = reader
+	return nil
+}
+
+// Unregister removes the reader for the tag if one is registered. Tagged
+// literals with the tag are read as TaggedLiteral values afterwards.
+func (t *Tags) Unregister(tag string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.readers, tag)
+}
+
+// reader returns the reader for the tag. nil Tags has only the built-in
+// tags.
+func (t *Tags) reader(tag string) (TagReader, bool) {
+	if t == nil {
+		reader, found := builtinTags()[tag]
+		return reader, found
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	reader, found := t.readers[tag]
+	return reader, found
+}
+
+func builtinTags() map[string]TagReader {
+	return map[string]TagReader{
+		"inst": readInst,
+		"uuid": readUUID,
+	}
+}
+
+// TaggedLiteral pairs a tag with its form. Tagged literals with tags that
+// have no registered reader are read as TaggedLiteral values. Tag readers
+// can also return it to keep the tag as part of the value (e.g. to pass tags
+// meant for another system through). It prints as the tagged literal it
+// represents.
+type TaggedLiteral struct {
+	Tag  string
+	Form interface{}
+}
+
+func (tl TaggedLiteral) String() string {
+	return fmt.Sprintf("#%s %v", tl.Tag, tl.Form)
+}
+
+// TaggedExpr represents a tagged literal (e.g. #inst "2018-11-03T10:00:00Z").
+// The form following the tag is read as data (see ReadData) and passed to
+// the tag reader while parsing. Value is the result of the reader.
+type TaggedExpr struct {
+	Tag   string
+	Form  interface{}
+	Value interface{}
+}
+
+// Eval returns the value read while parsing. The form is never evaluated.
+func (te TaggedExpr) Eval(scope Scope) (interface{}, error) {
+	return te.Value, nil
+}
+
+func (te TaggedExpr) String() string {
+	return fmt.Sprintf("#%s %s", te.Tag, Repr(te.Form))
+}
+
+// buildTaggedExpr reads the tag and the form following the '#' token and
+// passes the form to the reader registered for the tag. Tags without a
+// reader result in a TaggedLiteral. Forms rejected by the reader are syntax
+// errors.
+func buildTaggedExpr(hash *lexer.Token, tokens *tokenQueue) (Expr, error) {
+	tag := tokens.Pop()
+	if tag == nil || tag.Type != lexer.SYMBOL {
+		return nil, errors.New("expecting tag symbol after '#'")
+	}
+
+	formExpr, err := buildForm(tokens)
+	if err == ErrEOF {
+		return nil, fmt.Errorf("missing form after tag '#%s'", tag.Value)
+	} else if err != nil {
+		return nil, err
+	}
+
+	form, err := exprData(formExpr)
+	if err != nil {
+		return nil, tokens.newError(hash.Start, "%s", err)
+	}
+
+	expr := TaggedExpr{
+		Tag:   tag.Value,
+		Form:  form,
+		Value: TaggedLiteral{Tag: tag.Value, Form: form},
+	}
+
+	reader, found := tokens.tags.reader(tag.Value)
+	if tokens.skipTags || !found {
+		return expr, nil
+	}
+
+	expr.Value, err = reader(form)
+	if err != nil {
+		return nil, tokens.newError(hash.Start, "invalid #%s literal: %s", tag.Value, err)
+	}
+	return expr, nil
+}
+
+// instLayouts are the accepted formats for #inst literals ordered from the
+// most to the least precise.
+var instLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"2006-01",
+	"2006",
+}
+
+// readInst reads an RFC3339 timestamp string into time.Time. Trailing
+// parts of the timestamp may be omitted (e.g. "2018-11" or "2018") in
+// which case the time is assumed to be in UTC.
+func readInst(form interface{}) (interface{}, error) {
+	str, ok := form.(string)
+	if !ok {
+		return nil, fmt.Errorf("expecting string, not '%s'", reflect.TypeOf(form))
+	}
+
+	for _, layout := range instLayouts {
+		if t, err := time.Parse(layout, str); err == nil {
+			return t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("'%s' is not a valid RFC3339 timestamp", str)
+}
+
+// readUUID reads the canonical string form of an UUID.
+func readUUID(form interface{}) (interface{}, error) {
+	str, ok := form.(string)
+	if !ok {
+		return nil, fmt.Errorf("expecting string, not '%s'", reflect.TypeOf(form))
+	}
+
+	return ParseUUID(str)
+}
+
+// UUID is the value of #uuid tagged literals.
+type UUID [16]byte
+
+// ParseUUID parses the canonical string form of an UUID (e.g.
+// "f81d4fae-7dec-11d0-a765-00a0c91e6bf6").
+func ParseUUID(str string) (UUID, error) {
+	var uuid UUID
+
+	if len(str) != 36 || str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+		return uuid, fmt.Errorf("'%s' is not a valid UUID", str)
+	}
+
+	digits := str[0:8] + str[9:13] + str[14:18] + str[19:23] + str[24:]
+	if _, err := hex.Decode(uuid[:], []byte(digits)); err != nil {
+		return uuid, fmt.Errorf("'%s' is not a valid UUID", str)
+	}
+
+	return uuid, nil
+}
+
+func (uuid UUID) String() string {
+	str := hex.EncodeToString(uuid[:])
+	return str[0:8] + "-" + str[8:12] + "-" + str[12:16] + "-" + str[16:20] + "-" + str[20:]
+}
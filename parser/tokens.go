@@ -1,31 +1,148 @@
 package parser
 
-import "github.com/spy16/parens/lexer"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/spy16/parens/lexer"
+)
+
+// newTokenQueue tokenizes the src. Invalid tokens are recorded as syntax
+// errors and skipped. base is the position at which src starts in the
+// file. macros may be nil to disable custom reader macros and tags may be
+// nil to use only the built-in tags.
+func newTokenQueue(name, src string, base Position, macros *Macros, tags *Tags) *tokenQueue {
+	tq := &tokenQueue{
+		name:   name,
+		src:    src,
+		base:   base,
+		macros: macros,
+		tags:   tags,
+	}
+
+	lex := lexer.New(src)
+	lex.MacroChars = macros.chars()
+	lex.SkipTrivia = true
+
+	tq.tokens = make([]lexer.Token, 0, lex.EstimateTokens())
+	for {
+		token, err := lex.Next()
+		if err == lexer.ErrEOF {
+			return tq
+		} else if err != nil {
+			tq.errorAt(lex.Offset(), "%s", err)
+			continue
+		}
+
+		tq.tokens = append(tq.tokens, *token)
+	}
+}
+
+// tokenQueue provides sequential access to the tokens. Tokens are never
+// copied or removed, consuming a token only advances the position. Syntax
+// errors found while building the exprs are collected in errs.
 type tokenQueue struct {
 	tokens []lexer.Token
+	pos    int
+
+	name   string
+	src    string
+	base   Position
+	errs   ErrorList
+	macros *Macros
+	tags   *Tags
+
+	// skipTags disables the tag readers (e.g. while formatting where the
+	// tags used by the source may not be registered).
+	skipTags bool
 }
 
+// Token returns the token at given index (relative to the current position)
+// ignoring whitespaces, newlines and comments at the beginning of the queue.
 func (tq *tokenQueue) Token(index int) *lexer.Token {
-	if index >= len(tq.tokens) {
+	tq.skipTrivia()
+	if tq.pos+index >= len(tq.tokens) {
 		return nil
 	}
 
-	return &(tq.tokens[index])
+	return &tq.tokens[tq.pos+index]
 }
 
-// Pop removes and returns the the top element (i.e., 0th index) in the queue.
+// Pop returns the token at the current position and advances the position.
 func (tq *tokenQueue) Pop() *lexer.Token {
-	if len(tq.tokens) == 0 {
+	tq.skipTrivia()
+	if tq.pos >= len(tq.tokens) {
+		return nil
+	}
+
+	tq.pos++
+	return &tq.tokens[tq.pos-1]
+}
+
+// Err returns the syntax errors collected so far sorted by their position
+// or nil if there are none.
+func (tq *tokenQueue) Err() error {
+	if len(tq.errs) == 0 {
 		return nil
 	}
 
-	token := tq.tokens[0]
-	tq.tokens = tq.tokens[1:]
+	sort.SliceStable(tq.errs, func(i, j int) bool {
+		a, b := tq.errs[i].Pos, tq.errs[j].Pos
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	})
+	return tq.errs
+}
+
+// record adds the err as a syntax error. Errors that are not positioned are
+// reported at the start of the token.
+func (tq *tokenQueue) record(err error, token *lexer.Token) {
+	switch e := err.(type) {
+	case *SyntaxError:
+		tq.errs = append(tq.errs, e)
+
+	case ErrorList:
+		tq.errs = append(tq.errs, e...)
+
+	default:
+		if err == ErrEOF {
+			tq.errorAt(len(tq.src), "unexpected end of file")
+		} else {
+			tq.errorAt(token.Start, "%s", err)
+		}
+	}
+}
+
+func (tq *tokenQueue) errorAt(offset int, format string, args ...interface{}) {
+	tq.errs = append(tq.errs, tq.newError(offset, format, args...))
+}
+
+func (tq *tokenQueue) newError(offset int, format string, args ...interface{}) *SyntaxError {
+	return &SyntaxError{
+		Pos: tq.position(offset),
+		Msg: fmt.Sprintf(format, args...),
+	}
+}
+
+// position converts the offset in the source to line and column.
+func (tq *tokenQueue) position(offset int) Position {
+	pos := tq.base
+	pos.File = tq.name
+
+	before := tq.src[:offset]
+	if nl := strings.LastIndexByte(before, '\n'); nl >= 0 {
+		pos.Line += strings.Count(before, "\n")
+		pos.Column = 1 + utf8.RuneCountInString(before[nl+1:])
+	} else {
+		pos.Column += utf8.RuneCountInString(before)
+	}
+
+	return pos
+}
 
-	if token.Type == lexer.WHITESPACE || token.Type == lexer.NEWLINE ||
-		token.Type == lexer.COMMENT {
-		return tq.Pop()
+func (tq *tokenQueue) skipTrivia() {
+	for tq.pos < len(tq.tokens) && tq.tokens[tq.pos].IsTrivia() {
+		tq.pos++
 	}
-	return &token
 }
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/spy16/parens/lexer"
+	"github.com/spy16/parens/value"
 )
 
 // VectorExpr represents a vector form.
@@ -12,19 +13,28 @@ type VectorExpr struct {
 	List []Expr
 }
 
-// Eval creates a golang slice.
+// Eval evaluates the items and creates a *value.Vector.
 func (ve VectorExpr) Eval(scope Scope) (interface{}, error) {
-	lst := []interface{}{}
+	items, err := evalAll(scope, ve.List)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, expr := range ve.List {
+	return value.NewVector(items...), nil
+}
+
+// evalAll evaluates the exprs in order.
+func evalAll(scope Scope, exprs []Expr) ([]interface{}, error) {
+	vals := make([]interface{}, len(exprs))
+	for i, expr := range exprs {
 		val, err := expr.Eval(scope)
 		if err != nil {
 			return nil, err
 		}
-		lst = append(lst, val)
+		vals[i] = val
 	}
 
-	return lst, nil
+	return vals, nil
 }
 
 func (ve VectorExpr) String() string {
@@ -36,26 +46,8 @@ func (ve VectorExpr) String() string {
 	return fmt.Sprintf("[%s]", strings.Join(strs, " "))
 }
 
-func buildVectorExpr(tokens *tokenQueue) (Expr, error) {
-	ve := VectorExpr{}
-
-	for {
-		next := tokens.Token(0)
-		if next == nil {
-			return nil, ErrEOF
-		}
-		if next.Type == lexer.RVECT {
-			break
-		}
-		exp, err := buildExpr(tokens)
-		if err != nil {
-			return nil, err
-		}
-
-		if exp != nil {
-			ve.List = append(ve.List, exp)
-		}
-	}
-	tokens.Pop()
-	return ve, nil
+func buildVectorExpr(opener *lexer.Token, tokens *tokenQueue) (Expr, error) {
+	return VectorExpr{
+		List: buildItems(tokens, opener, lexer.RVECT, "vector"),
+	}, nil
 }
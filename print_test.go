@@ -0,0 +1,101 @@
+package parens_test
+
+import (
+	"math"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepr(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("RoundTrip", func(t *testing.T) {
+		cases := []string{
+			`nil`,
+			`true`,
+			`42`,
+			`-7`,
+			`1.5`,
+			`2.0`,
+			`1e+21`,
+			`##Inf`,
+			`##-Inf`,
+			`123456789012345678901234567890N`,
+			`3/4`,
+			`"hello \"world\"\n\ttab \\ é"`,
+			`\a`,
+			`\newline`,
+			`\space`,
+			`\u0001`,
+			`:keyword`,
+			`:user/id`,
+			`[1 "two" [3.0 false]]`,
+			`{:a 1 :b [true false]}`,
+			`#{1 2 3}`,
+			`#inst "2018-11-03T10:00:00.5Z"`,
+			`#uuid "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"`,
+		}
+
+		for _, src := range cases {
+			val, err := executeWithStdlib(t, src)
+			require.NoError(t, err, src)
+			assert.Equal(t, src, parser.Repr(val), src)
+
+			again, err := executeWithStdlib(t, parser.Repr(val))
+			require.NoError(t, err, src)
+			assert.Equal(t, val, again, src)
+		}
+	})
+
+	suite.Run("GoValues", func(t *testing.T) {
+		cases := []struct {
+			val  interface{}
+			repr string
+		}{
+			{val: nil, repr: "nil"},
+			{val: int(10), repr: "10"},
+			{val: uint8(255), repr: "255"},
+			{val: float32(1), repr: "1.0"},
+			{val: []string{"a", "b"}, repr: `["a" "b"]`},
+			{val: map[string]int{"b": 2, ":a": 1}, repr: `{":a" 1 "b" 2}`},
+			{val: map[value.Keyword]int{value.ParseKeyword("b"): 2}, repr: `{:b 2}`},
+			{val: value.ParseSymbol("user/id"), repr: `user/id`},
+			{val: []interface{}(nil), repr: "[]"},
+			{val: (*big.Int)(nil), repr: "nil"},
+			{val: big.NewInt(5), repr: "5N"},
+			{val: regexp.MustCompile(`\d+`), repr: `#"\d+"`},
+			{val: time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC), repr: `#inst "2018-01-02T00:00:00Z"`},
+			{val: parser.TaggedLiteral{Tag: "point", Form: []interface{}{int64(1), int64(2)}}, repr: "#point [1 2]"},
+			{val: func(int) int { return 0 }, repr: "<function: func(int) int>"},
+		}
+
+		for _, cs := range cases {
+			assert.Equal(t, cs.repr, parser.Repr(cs.val))
+		}
+	})
+
+	suite.Run("NaN", func(t *testing.T) {
+		val, err := executeWithStdlib(t, parser.Repr(math.NaN()))
+		require.NoError(t, err)
+		assert.True(t, math.IsNaN(val.(float64)))
+	})
+
+	suite.Run("PrStr", func(t *testing.T) {
+		val, err := executeWithStdlib(t, `(pr-str nil [nil ##Inf])`)
+		require.NoError(t, err)
+		assert.Equal(t, "nil [nil ##Inf]", val)
+	})
+
+	suite.Run("QuotedForms", func(t *testing.T) {
+		val, err := executeWithStdlib(t, `'(+ 1 [2 {:a "x"}])`)
+		require.NoError(t, err)
+		assert.Equal(t, `(+ 1 [2 {:a "x"}])`, parser.Repr(val))
+	})
+}
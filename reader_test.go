@@ -0,0 +1,150 @@
+package parens_test
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/spy16/parens"
+	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader_ReadSource(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("Forms", func(t *testing.T) {
+		src := "(+ 1 2) [1 (2 3)]\n; comment\n{:a \"}\"}  sym 'quoted #{1 2} #(+ %) #\"\\\"\"" +
+			" `raw)` \\) \\space #_ (ignored) #inst \"2018\"" +
+			" #_ #_ a b 'c '#_ d e #_ (ignored at end) ; trailing comment"
+
+		expected := []string{
+			"(+ 1 2)",
+			"[1 (2 3)]",
+			"{:a \"}\"}",
+			"sym",
+			"'quoted",
+			"#{1 2}",
+			"#(+ %)",
+			"#\"\\\"\"",
+			"`raw)`",
+			"\\)",
+			"\\space",
+			"#_ (ignored) #inst \"2018\"",
+			"#_ #_ a b 'c",
+			"'#_ d e",
+		}
+
+		rd := parser.NewReader("<test>", iotest.OneByteReader(strings.NewReader(src)))
+		for _, form := range expected {
+			actual, err := rd.ReadSource()
+			require.NoError(t, err)
+			assert.Equal(t, form, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(actual), "; comment")))
+		}
+
+		_, err := rd.ReadSource()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	suite.Run("UnexpectedEOF", func(t *testing.T) {
+		for _, src := range []string{`(+ 1`, `"abc`, `'`, `#_`, `[1 ; comment`, "`raw"} {
+			_, err := parser.NewReader("<test>", strings.NewReader(src)).ReadSource()
+			assert.Equal(t, parser.ErrUnexpectedEOF, err, src)
+		}
+	})
+
+	suite.Run("SplitRunes", func(t *testing.T) {
+		rd := parser.NewReader("<test>", iotest.OneByteReader(strings.NewReader(`"λ" λx \λ`)))
+
+		for _, form := range []string{`"λ"`, ` λx`, ` \λ`} {
+			src, err := rd.ReadSource()
+			require.NoError(t, err)
+			assert.Equal(t, form, src)
+		}
+	})
+
+	suite.Run("UnexpectedClose", func(t *testing.T) {
+		rd := parser.NewReader("<test>", strings.NewReader(`) (a)`))
+
+		_, err := rd.ReadSource()
+		assert.Error(t, err)
+
+		src, err := rd.ReadSource()
+		require.NoError(t, err)
+		assert.Equal(t, " (a)", src)
+	})
+}
+
+func TestReader_Read(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("SkipsDiscarded", func(t *testing.T) {
+		rd := parser.NewReader("<test>", strings.NewReader(`#_ 1 2 #_ #_ 3 4 5`))
+
+		expr, err := rd.Read()
+		require.NoError(t, err)
+		assert.Equal(t, "2", fmt.Sprint(expr))
+
+		expr, err = rd.Read()
+		require.NoError(t, err)
+		assert.Equal(t, "5", fmt.Sprint(expr))
+
+		_, err = rd.Read()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	suite.Run("Incremental", func(t *testing.T) {
+		pr, pw := io.Pipe()
+		defer pw.Close()
+
+		go func() {
+			fmt.Fprint(pw, "(first form)\n(second")
+		}()
+
+		rd := parser.NewReader("<test>", pr)
+		expr, err := rd.Read()
+		require.NoError(t, err)
+		assert.Equal(t, "(first form)", fmt.Sprint(expr))
+	})
+
+	suite.Run("ParseError", func(t *testing.T) {
+		_, err := parser.NewReader("<test>", strings.NewReader(`(1 #"[")`)).Read()
+		assert.Error(t, err)
+	})
+}
+
+func TestExecuteReader(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("Success", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		require.NoError(t, stdlib.RegisterAll(scope))
+
+		src := "(label x 10)\n(label y\n  (+ x 5))\n; result\n(* x y)\n"
+		res, err := parens.New(scope).ExecuteReader("<test>", strings.NewReader(src))
+		require.NoError(t, err)
+		assert.Equal(t, int64(150), res)
+	})
+
+	suite.Run("EvaluatesBeforeReadingRest", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		count := 0
+		scope.Bind("inc", func() { count++ })
+
+		_, err := parens.New(scope).ExecuteReader("<test>", strings.NewReader(`(inc) (inc) (inc`))
+		assert.Equal(t, parser.ErrUnexpectedEOF, err)
+		assert.Equal(t, 2, count)
+	})
+
+	suite.Run("Panic", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		scope.Bind("fail", func() { panic("failed") })
+
+		_, err := parens.New(scope).ExecuteReader("<test>", strings.NewReader(`(fail)`))
+		assert.EqualError(t, err, "panic: failed")
+	})
+}
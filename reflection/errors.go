@@ -1,6 +1,10 @@
 package reflection
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
 
 var (
 	// ErrNameNotFound is returned when a lookup is performed with a
@@ -19,3 +23,52 @@ var (
 	// with invalid number of arguments.
 	ErrInvalidNumberOfArgs = errors.New("invalid number of arguments")
 )
+
+// ArgCountError is returned when a function is called with invalid number
+// of arguments.
+type ArgCountError struct {
+	// Expected is the exact number of arguments required or the minimum
+	// number of arguments in case of a variadic function.
+	Expected int
+	Actual   int
+	Variadic bool
+}
+
+func (err ArgCountError) Error() string {
+	if err.Variadic {
+		return fmt.Sprintf("call requires at-least %d arguments, got %d", err.Expected, err.Actual)
+	}
+	return fmt.Sprintf("call requires exactly %d arguments, got %d", err.Expected, err.Actual)
+}
+
+// Is returns true if target is ErrInvalidNumberOfArgs.
+func (err ArgCountError) Is(target error) bool {
+	return target == ErrInvalidNumberOfArgs
+}
+
+// ArgTypeError is returned when an argument cannot be converted to the type
+// of the parameter it is passed to.
+type ArgTypeError struct {
+	// Position is the zero-based index of the argument.
+	Position int
+	Expected reflect.Type
+
+	// Actual is the type of the argument. Actual will be nil if the
+	// argument was nil.
+	Actual reflect.Type
+}
+
+func (err ArgTypeError) Error() string {
+	actual := "nil"
+	if err.Actual != nil {
+		actual = err.Actual.String()
+	}
+
+	return fmt.Sprintf("invalid type for argument %d: expected=%s, actual=%s",
+		err.Position+1, err.Expected, actual)
+}
+
+// Is returns true if target is ErrConversionImpossible.
+func (err ArgTypeError) Is(target error) bool {
+	return target == ErrConversionImpossible
+}
@@ -1,10 +1,17 @@
 package reflection_test
 
 import (
+	"errors"
+	"net"
 	"reflect"
+	"strings"
 	"testing"
+	"unicode"
 
 	"github.com/spy16/parens/reflection"
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func add2(a, b int) int {
@@ -19,8 +26,281 @@ func addAll(vals ...float64) float64 {
 	return sum
 }
 
-var addFunc = reflect.ValueOf(add2)
-var sumFunc = reflect.ValueOf(addAll)
+func TestCall(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("NonVariadic", func(t *testing.T) {
+		res, err := reflection.Call(add2, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 3, res)
+	})
+
+	suite.Run("Variadic", func(t *testing.T) {
+		res, err := reflection.Call(addAll, 1.0, 2.0, 3.0)
+		require.NoError(t, err)
+		assert.Equal(t, 6.0, res)
+	})
+
+	suite.Run("WithTypeConversion", func(t *testing.T) {
+		res, err := reflection.Call(add2, 1.0, int64(2))
+		require.NoError(t, err)
+		assert.Equal(t, 3, res)
+	})
+
+	suite.Run("MultipleReturns", func(t *testing.T) {
+		res, err := reflection.Call(func(a int) (int, string) { return a, "ok" }, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{10, "ok"}, res)
+	})
+
+	suite.Run("InvalidArgCount", func(t *testing.T) {
+		_, err := reflection.Call(add2, 1)
+		assert.Error(t, err)
+	})
+
+	suite.Run("NotCallable", func(t *testing.T) {
+		_, err := reflection.Call("hello", 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestCall_TextConversions(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("RuneToString", func(t *testing.T) {
+		res, err := reflection.Call(strings.Repeat, 'π', 2)
+		require.NoError(t, err)
+		assert.Equal(t, "ππ", res)
+	})
+
+	suite.Run("StringToRune", func(t *testing.T) {
+		res, err := reflection.Call(unicode.IsUpper, "A")
+		require.NoError(t, err)
+		assert.Equal(t, true, res)
+	})
+
+	suite.Run("StringToByte", func(t *testing.T) {
+		res, err := reflection.Call(func(b byte) byte { return b }, "a")
+		require.NoError(t, err)
+		assert.Equal(t, byte('a'), res)
+
+		_, err = reflection.Call(func(b byte) byte { return b }, "π")
+		assert.Error(t, err)
+	})
+
+	suite.Run("StringToSlices", func(t *testing.T) {
+		res, err := reflection.Call(func(b []byte, r []rune) int { return len(b) + len(r) }, "π", "π")
+		require.NoError(t, err)
+		assert.Equal(t, 3, res)
+	})
+
+	suite.Run("MultiCharacterString", func(t *testing.T) {
+		_, err := reflection.Call(unicode.IsUpper, "AB")
+		assert.Error(t, err)
+	})
+}
+
+func TestCall_Collections(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("Map", func(t *testing.T) {
+		m, err := value.NewMap(":a", int64(1), ":b", int64(2))
+		require.NoError(t, err)
+
+		res, err := reflection.Call(func(m map[string]int) int { return m[":a"] + m[":b"] }, m)
+		require.NoError(t, err)
+		assert.Equal(t, 3, res)
+
+		res, err = reflection.Call(func(m map[string]interface{}) int { return len(m) }, m)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res)
+	})
+
+	suite.Run("MapInvalidValue", func(t *testing.T) {
+		m, err := value.NewMap(":a", "not a number")
+		require.NoError(t, err)
+
+		_, err = reflection.Call(func(m map[string]int) {}, m)
+		assert.Error(t, err)
+	})
+
+	suite.Run("Vector", func(t *testing.T) {
+		vec := value.NewVector(int64(1), int64(2), int64(3))
+
+		res, err := reflection.Call(func(s []int) int { return s[0] + s[2] }, vec)
+		require.NoError(t, err)
+		assert.Equal(t, 4, res)
+
+		res, err = reflection.Call(func(a [3]float64) float64 { return a[1] }, vec)
+		require.NoError(t, err)
+		assert.Equal(t, 2.0, res)
+
+		_, err = reflection.Call(func(a [2]int) {}, vec)
+		assert.Error(t, err)
+
+		_, err = reflection.Call(func(s []string) {}, vec)
+		assert.Error(t, err)
+	})
+
+	suite.Run("Set", func(t *testing.T) {
+		set, err := value.NewSet("a", "b")
+		require.NoError(t, err)
+
+		res, err := reflection.Call(func(m map[string]struct{}) int { return len(m) }, set)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res)
+
+		res, err = reflection.Call(func(m map[string]bool) bool { return m["a"] && m["b"] }, set)
+		require.NoError(t, err)
+		assert.Equal(t, true, res)
+
+		res, err = reflection.Call(func(s []string) string { return s[0] + s[1] }, set)
+		require.NoError(t, err)
+		assert.Equal(t, "ab", res)
+	})
+
+	suite.Run("Nested", func(t *testing.T) {
+		m, err := value.NewMap("a", value.NewVector(int64(1), int64(2)))
+		require.NoError(t, err)
+
+		res, err := reflection.Call(func(m map[string][]int) int { return m["a"][1] }, m)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res)
+	})
+
+	suite.Run("Returns", func(t *testing.T) {
+		res, err := reflection.Call(func() []int { return []int{1, 2} })
+		require.NoError(t, err)
+		assert.Equal(t, value.NewVector(1, 2), res)
+
+		res, err = reflection.Call(func() map[string][]int { return map[string][]int{"a": {1}} })
+		require.NoError(t, err)
+		expected, _ := value.NewMap("a", value.NewVector(1))
+		assert.Equal(t, expected, res)
+
+		res, err = reflection.Call(func() ([]string, error) { return []string{"a"}, nil })
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{value.NewVector("a"), nil}, res)
+	})
+
+	suite.Run("ReturnsKeepTypes", func(t *testing.T) {
+		ip := net.IPv4(127, 0, 0, 1)
+		res, err := reflection.Call(func() net.IP { return ip })
+		require.NoError(t, err)
+		assert.Equal(t, ip, res)
+
+		res, err = reflection.Call(func() []byte { return []byte("abc") })
+		require.NoError(t, err)
+		assert.Equal(t, []byte("abc"), res)
+	})
+
+	suite.Run("Interface", func(t *testing.T) {
+		vec := value.NewVector(1)
+
+		res, err := reflection.Call(func(v interface{}) interface{} { return v }, vec)
+		require.NoError(t, err)
+		assert.True(t, res == vec)
+	})
+}
+
+func TestCall_NilArgs(suite *testing.T) {
+	suite.Parallel()
+
+	isNil := func(v interface{}) bool { return v == nil }
+
+	suite.Run("Interface", func(t *testing.T) {
+		res, err := reflection.Call(isNil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, true, res)
+	})
+
+	suite.Run("NilableTypes", func(t *testing.T) {
+		fn := func(p *int, s []int, m map[string]int, e error) bool {
+			return p == nil && s == nil && m == nil && e == nil
+		}
+
+		res, err := reflection.Call(fn, nil, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, true, res)
+	})
+
+	suite.Run("Variadic", func(t *testing.T) {
+		fn := func(vals ...*int) int { return len(vals) }
+
+		res, err := reflection.Call(fn, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res)
+	})
+
+	suite.Run("NonNilable", func(t *testing.T) {
+		_, err := reflection.Call(add2, 1, nil)
+		require.Error(t, err)
+
+		typeErr, ok := err.(*reflection.ArgTypeError)
+		require.True(t, ok)
+		assert.Equal(t, 1, typeErr.Position)
+		assert.Equal(t, reflect.TypeOf(0), typeErr.Expected)
+		assert.Nil(t, typeErr.Actual)
+	})
+}
+
+func TestCall_ArgErrors(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("ExactArity", func(t *testing.T) {
+		_, err := reflection.Call(add2, 1, 2, 3)
+		assert.Equal(t, &reflection.ArgCountError{Expected: 2, Actual: 3}, err)
+		assert.True(t, errors.Is(err, reflection.ErrInvalidNumberOfArgs))
+	})
+
+	suite.Run("VariadicMinimumArity", func(t *testing.T) {
+		fn := func(format string, args ...interface{}) string { return format }
+
+		_, err := reflection.Call(fn)
+		assert.Equal(t, &reflection.ArgCountError{Expected: 1, Actual: 0, Variadic: true}, err)
+	})
+
+	suite.Run("ArgType", func(t *testing.T) {
+		_, err := reflection.Call(addAll, 1.0, "two")
+		assert.Equal(t, &reflection.ArgTypeError{
+			Position: 1,
+			Expected: reflect.TypeOf(0.0),
+			Actual:   reflect.TypeOf(""),
+		}, err)
+		assert.True(t, errors.Is(err, reflection.ErrConversionImpossible))
+	})
+
+	suite.Run("InterfaceNotImplemented", func(t *testing.T) {
+		fn := func(err error) string { return err.Error() }
+
+		_, err := reflection.Call(fn, 10)
+		assert.IsType(t, &reflection.ArgTypeError{}, err)
+	})
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	rv, err := reflection.Convert(value.NewVector(int64(1), int64(2)), reflect.TypeOf([]int{}))
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, rv.Interface())
+
+	rv, err = reflection.Convert(int64(3), reflect.TypeOf(uint8(0)))
+	require.NoError(t, err)
+	assert.Equal(t, uint8(3), rv.Interface())
+
+	_, err = reflection.Convert("a", reflect.TypeOf(0))
+	assert.EqualError(t, err, "value of type 'string' cannot be converted to 'int'")
+	assert.True(t, errors.Is(err, reflection.ErrConversionImpossible))
+}
+
+func TestCall_CachedPlanAllocations(t *testing.T) {
+	reflection.Call(add2, 1, 2)
+	allocs := testing.AllocsPerRun(100, func() {
+		reflection.Call(add2, 1, 2)
+	})
+	assert.True(t, allocs <= 3, "expected at most 3 allocations, got %f", allocs)
+}
 
 func BenchmarkNonVariadicCall(suite *testing.B) {
 	suite.Run("Normal", func(b *testing.B) {
@@ -30,16 +310,18 @@ func BenchmarkNonVariadicCall(suite *testing.B) {
 	})
 
 	suite.Run("Reflection", func(b *testing.B) {
+		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
-			reflection.Call(addFunc, 1, 2)
+			reflection.Call(add2, 1, 2)
 		}
 	})
 
 	suite.Run("WithTypeConversion", func(b *testing.B) {
-		// addFunc expects float64 but forcefully passing int64 which
+		// add2 expects int but forcefully passing int64 which
 		// triggers type-conversion in parens' reflection package.
+		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
-			reflection.Call(addFunc, []interface{}{int64(1), int64(2)}...)
+			reflection.Call(add2, []interface{}{int64(1), int64(2)}...)
 		}
 	})
 }
@@ -52,16 +334,18 @@ func BenchmarkVariadicCall(suite *testing.B) {
 	})
 
 	suite.Run("Reflection", func(b *testing.B) {
+		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
-			reflection.Call(sumFunc, 1, 2)
+			reflection.Call(addAll, 1.0, 2.0)
 		}
 	})
 
 	suite.Run("WithTypeConversion", func(b *testing.B) {
-		// sumFunc expects float64 but forcefully passing int64 which
+		// addAll expects float64 but forcefully passing int64 which
 		// triggers type-conversion in parens' reflection package.
+		b.ReportAllocs()
 		for i := 0; i < b.N; i++ {
-			reflection.Call(sumFunc, []interface{}{int64(1), int64(2)}...)
+			reflection.Call(addAll, []interface{}{int64(1), int64(2)}...)
 		}
 	})
 }
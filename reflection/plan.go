@@ -0,0 +1,238 @@
+package reflection
+
+import (
+	"reflect"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/spy16/parens/value"
+)
+
+// plans caches the call plan of every function type that has been
+// called through Call so far.
+var plans sync.Map // map[reflect.Type]*callPlan
+
+// callPlan holds everything Call needs to know about a function type
+// so that the type need not be inspected again on every call.
+type callPlan struct {
+	numIn    int
+	numOut   int
+	variadic bool
+
+	// non-variadic parameters of the function.
+	params []param
+
+	// element type of the variadic parameter. Only valid if the
+	// function is variadic.
+	varParam param
+}
+
+// param is a single parameter of a function and the converter for
+// turning arguments into the parameter type.
+type param struct {
+	typ  reflect.Type
+	conv converter
+}
+
+// converter converts a value to the parameter type it was created for.
+// ErrConversionImpossible is returned if the conversion is not possible.
+type converter func(v interface{}) (reflect.Value, error)
+
+// planFor returns the cached call plan for the function type. If no plan
+// exists yet, a new one is created and cached.
+func planFor(rType reflect.Type) *callPlan {
+	if plan, found := plans.Load(rType); found {
+		return plan.(*callPlan)
+	}
+
+	plan, _ := plans.LoadOrStore(rType, newCallPlan(rType))
+	return plan.(*callPlan)
+}
+
+func newCallPlan(rType reflect.Type) *callPlan {
+	plan := &callPlan{
+		numIn:    rType.NumIn(),
+		numOut:   rType.NumOut(),
+		variadic: rType.IsVariadic(),
+	}
+
+	fixed := plan.numIn
+	if plan.variadic {
+		fixed--
+		plan.varParam = newParam(rType.In(fixed).Elem())
+	}
+
+	plan.params = make([]param, fixed)
+	for i := 0; i < fixed; i++ {
+		plan.params[i] = newParam(rType.In(i))
+	}
+
+	return plan
+}
+
+// makeArgs converts the args to the parameter types of the function as
+// described by the plan.
+func (plan *callPlan) makeArgs(args []interface{}) ([]reflect.Value, error) {
+	if plan.variadic && len(args) < len(plan.params) {
+		return nil, &ArgCountError{
+			Expected: len(plan.params),
+			Actual:   len(args),
+			Variadic: true,
+		}
+	} else if !plan.variadic && len(args) != plan.numIn {
+		return nil, &ArgCountError{
+			Expected: plan.numIn,
+			Actual:   len(args),
+		}
+	}
+
+	argVals := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		p := plan.varParam
+		if i < len(plan.params) {
+			p = plan.params[i]
+		}
+
+		val, err := p.conv(arg)
+		if err != nil {
+			return nil, &ArgTypeError{
+				Position: i,
+				Expected: p.typ,
+				Actual:   reflect.TypeOf(arg),
+			}
+		}
+		argVals[i] = val
+	}
+
+	return argVals, nil
+}
+
+// wrapReturn converts the return values of a call into a single value.
+// Functions with more than one return value result in []interface{}.
+// Go slices and maps of unnamed types in the return values are converted
+// to the persistent collections using value.FromGo.
+func (plan *callPlan) wrapReturn(retVals []reflect.Value) interface{} {
+	switch plan.numOut {
+	case 0:
+		return nil
+
+	case 1:
+		return value.FromGo(retVals[0].Interface())
+
+	default:
+		wrapped := make([]interface{}, len(retVals))
+		for i, retVal := range retVals {
+			wrapped[i] = value.FromGo(retVal.Interface())
+		}
+		return wrapped
+	}
+}
+
+func newParam(typ reflect.Type) param {
+	return param{
+		typ:  typ,
+		conv: newConverter(typ),
+	}
+}
+
+func newConverter(expected reflect.Type) converter {
+	if expected.Kind() == reflect.Interface {
+		return func(v interface{}) (reflect.Value, error) {
+			if v == nil {
+				return reflect.Zero(expected), nil
+			}
+
+			rv := reflect.ValueOf(v)
+			if !rv.Type().Implements(expected) {
+				return reflect.Value{}, ErrConversionImpossible
+			}
+			return rv, nil
+		}
+	}
+
+	nilable := isNilableKind(expected.Kind())
+	numeric := isNumberKind(expected.Kind())
+	return func(v interface{}) (reflect.Value, error) {
+		if v == nil {
+			if nilable {
+				return reflect.Zero(expected), nil
+			}
+			return reflect.Value{}, ErrConversionImpossible
+		}
+
+		rv := reflect.ValueOf(v)
+		if rv.Type() == expected {
+			return rv, nil
+		}
+
+		if numeric && isNumberKind(rv.Kind()) {
+			return rv.Convert(expected), nil
+		}
+
+		if converted, ok := convertText(rv, expected); ok {
+			return converted, nil
+		}
+
+		if converted, ok := convertIdent(v, expected); ok {
+			return converted, nil
+		}
+
+		if converted, ok := convertCollection(v, expected); ok {
+			return converted, nil
+		}
+
+		return convertValueType(v, expected)
+	}
+}
+
+// convertText converts between rune, byte and string values. A rune or byte
+// can be passed where a string is expected, a single character string can be
+// passed where a rune or byte is expected and a string can be passed where
+// []rune or []byte is expected.
+func convertText(rv reflect.Value, expected reflect.Type) (reflect.Value, bool) {
+	switch {
+	case expected.Kind() == reflect.String && (rv.Kind() == reflect.Int32 || rv.Kind() == reflect.Uint8):
+		var str string
+		if rv.Kind() == reflect.Int32 {
+			str = string(rune(rv.Int()))
+		} else {
+			str = string(rune(rv.Uint()))
+		}
+		return reflect.ValueOf(str).Convert(expected), true
+
+	case rv.Kind() == reflect.String && (expected.Kind() == reflect.Int32 || expected.Kind() == reflect.Uint8):
+		str := rv.String()
+		ru, width := utf8.DecodeRuneInString(str)
+		if width == 0 || width != len(str) || (expected.Kind() == reflect.Uint8 && ru > 0xFF) {
+			return reflect.Value{}, false
+		}
+		return reflect.ValueOf(ru).Convert(expected), true
+
+	case rv.Kind() == reflect.String && expected.Kind() == reflect.Slice:
+		elem := expected.Elem().Kind()
+		if elem == reflect.Int32 || elem == reflect.Uint8 {
+			return rv.Convert(expected), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+func isNilableKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map,
+		reflect.Chan, reflect.Func:
+		return true
+	}
+	return false
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,256 @@
+package reflection
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var defaultTypes = NewTypeRegistry()
+
+// RegisterType registers the type of v with the given name in the default
+// type registry. See TypeRegistry.Register.
+func RegisterType(name string, v interface{}) error {
+	return defaultTypes.Register(name, v)
+}
+
+// RegisterConstructor registers a constructor function in the default type
+// registry. See TypeRegistry.RegisterConstructor.
+func RegisterConstructor(name string, fn interface{}) error {
+	return defaultTypes.RegisterConstructor(name, fn)
+}
+
+// LookupType finds the type with given name in the default type registry.
+func LookupType(name string) (reflect.Type, error) {
+	return defaultTypes.Lookup(name)
+}
+
+// DefaultTypes returns the default type registry.
+func DefaultTypes() *TypeRegistry {
+	return defaultTypes
+}
+
+// NewTypeRegistry initializes a type registry with all the basic Go types
+// (bool, string, int, float64, any etc.) registered.
+func NewTypeRegistry() *TypeRegistry {
+	tr := &TypeRegistry{
+		types: map[string]reflect.Type{},
+		ctors: map[string]interface{}{},
+	}
+
+	basics := []interface{}{
+		false, "",
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+		float32(0), float64(0),
+	}
+	for _, v := range basics {
+		tr.types[reflect.TypeOf(v).String()] = reflect.TypeOf(v)
+	}
+
+	anyType := reflect.TypeOf((*interface{})(nil)).Elem()
+	tr.types["byte"] = reflect.TypeOf(byte(0))
+	tr.types["rune"] = reflect.TypeOf(rune(0))
+	tr.types["any"] = anyType
+	tr.types["interface{}"] = anyType
+	tr.types["error"] = reflect.TypeOf((*error)(nil)).Elem()
+	return tr
+}
+
+// TypeRegistry maps names to Go types so that values of those types can be
+// created and inspected by name.
+type TypeRegistry struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+	ctors map[string]interface{}
+}
+
+// Register registers the type of v with the given name. v can be a value
+// of the type or a reflect.Type. To register an interface type, pass a nil
+// pointer to the interface (e.g. (*io.Reader)(nil)).
+func (tr *TypeRegistry) Register(name string, v interface{}) error {
+	if len(strings.TrimSpace(name)) == 0 {
+		return fmt.Errorf("type name must not be empty")
+	}
+
+	rType, ok := v.(reflect.Type)
+	if !ok {
+		if v == nil {
+			return fmt.Errorf("cannot register type of nil as '%s'", name)
+		}
+
+		rType = reflect.TypeOf(v)
+		if rType.Kind() == reflect.Ptr && rType.Elem().Kind() == reflect.Interface {
+			rType = rType.Elem()
+		}
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.types[name] = rType
+	return nil
+}
+
+// RegisterConstructor registers a constructor function for the type name.
+// The type returned by the function (first return value) is registered with
+// the name as well. When a constructor is registered, New calls it with the
+// arguments instead of initializing the fields.
+func (tr *TypeRegistry) RegisterConstructor(name string, fn interface{}) error {
+	rType := reflect.TypeOf(fn)
+	if rType == nil || rType.Kind() != reflect.Func || rType.NumOut() == 0 {
+		return fmt.Errorf("constructor for '%s' must be a function with return value", name)
+	}
+
+	if err := tr.Register(name, rType.Out(0)); err != nil {
+		return err
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.ctors[name] = fn
+	return nil
+}
+
+// HasConstructor returns true if a constructor is registered for the name.
+func (tr *TypeRegistry) HasConstructor(name string) bool {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	_, found := tr.ctors[name]
+	return found
+}
+
+// Lookup returns the type registered with the name. Names can be prefixed
+// with '*' or '[]' to get pointer or slice of a registered type.
+func (tr *TypeRegistry) Lookup(name string) (reflect.Type, error) {
+	switch {
+	case strings.HasPrefix(name, "*"):
+		elem, err := tr.Lookup(name[1:])
+		if err != nil {
+			return nil, err
+		}
+		return reflect.PtrTo(elem), nil
+
+	case strings.HasPrefix(name, "[]"):
+		elem, err := tr.Lookup(name[2:])
+		if err != nil {
+			return nil, err
+		}
+		return reflect.SliceOf(elem), nil
+	}
+
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	rType, found := tr.types[name]
+	if !found {
+		return nil, fmt.Errorf("type '%s' is not registered", name)
+	}
+	return rType, nil
+}
+
+// New creates a new value of the named type. If a constructor is registered
+// for the name, the result of calling it with args is returned. Otherwise, a
+// pointer to a new zero value is returned. In the latter case, a single map of
+// field names to values can be passed to initialize struct fields.
+func (tr *TypeRegistry) New(name string, args ...interface{}) (interface{}, error) {
+	tr.mu.RLock()
+	ctor, hasCtor := tr.ctors[name]
+	tr.mu.RUnlock()
+
+	if hasCtor {
+		return Call(ctor, args...)
+	}
+
+	rType, err := tr.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ptr := reflect.New(rType)
+	if len(args) == 0 {
+		return ptr.Interface(), nil
+	}
+
+	fields, ok := args[0].(map[string]interface{})
+	if len(args) > 1 || !ok {
+		return nil, fmt.Errorf("type '%s' has no constructor, expecting a single map of fields", name)
+	}
+
+	if err := setFields(ptr.Elem(), fields); err != nil {
+		return nil, err
+	}
+
+	return ptr.Interface(), nil
+}
+
+// MakeSlice creates a slice of the named element type with given length.
+func (tr *TypeRegistry) MakeSlice(elemName string, length int) (interface{}, error) {
+	elem, err := tr.Lookup(elemName)
+	if err != nil {
+		return nil, err
+	}
+
+	return reflect.MakeSlice(reflect.SliceOf(elem), length, length).Interface(), nil
+}
+
+// MakeMap creates an empty map of the named key and value types.
+func (tr *TypeRegistry) MakeMap(keyName, valName string) (interface{}, error) {
+	key, err := tr.Lookup(keyName)
+	if err != nil {
+		return nil, err
+	}
+
+	val, err := tr.Lookup(valName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !key.Comparable() {
+		return nil, fmt.Errorf("invalid map key type '%s'", key)
+	}
+
+	return reflect.MakeMap(reflect.MapOf(key, val)).Interface(), nil
+}
+
+// IsInstance returns true if the value is of the named type. If the named
+// type is an interface, returns true if the value implements it.
+func (tr *TypeRegistry) IsInstance(name string, v interface{}) (bool, error) {
+	rType, err := tr.Lookup(name)
+	if err != nil {
+		return false, err
+	}
+
+	if v == nil {
+		return false, nil
+	}
+
+	vType := reflect.TypeOf(v)
+	if rType.Kind() == reflect.Interface {
+		return vType.Implements(rType), nil
+	}
+	return vType == rType, nil
+}
+
+func setFields(obj reflect.Value, fields map[string]interface{}) error {
+	if obj.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot set fields on value of type '%s'", obj.Type())
+	}
+
+	for name, v := range fields {
+		field := obj.FieldByName(name)
+		if !field.IsValid() || !field.CanSet() {
+			return fmt.Errorf("no settable field '%s' on '%s'", name, obj.Type())
+		}
+
+		val, err := newConverter(field.Type())(v)
+		if err != nil {
+			return fmt.Errorf("invalid value for field '%s': expected=%s, actual=%s",
+				name, field.Type(), reflect.TypeOf(v))
+		}
+		field.Set(val)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package reflection_test
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spy16/parens/reflection"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type point struct {
+	X, Y int
+	Name string
+}
+
+func newPoint(x, y int) point {
+	return point{X: x, Y: y}
+}
+
+func TestTypeRegistry(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("BuiltinTypes", func(t *testing.T) {
+		tr := reflection.NewTypeRegistry()
+
+		rType, err := tr.Lookup("int")
+		require.NoError(t, err)
+		assert.Equal(t, reflect.TypeOf(0), rType)
+
+		rType, err = tr.Lookup("[]*string")
+		require.NoError(t, err)
+		assert.Equal(t, reflect.TypeOf([]*string{}), rType)
+	})
+
+	suite.Run("UnknownType", func(t *testing.T) {
+		_, err := reflection.NewTypeRegistry().Lookup("Unknown")
+		assert.Error(t, err)
+	})
+
+	suite.Run("NewWithFields", func(t *testing.T) {
+		tr := reflection.NewTypeRegistry()
+		require.NoError(t, tr.Register("Point", point{}))
+
+		val, err := tr.New("Point", map[string]interface{}{"X": 1.0, "Name": "origin"})
+		require.NoError(t, err)
+		assert.Equal(t, &point{X: 1, Name: "origin"}, val)
+
+		_, err = tr.New("Point", map[string]interface{}{"Z": 1})
+		assert.Error(t, err)
+	})
+
+	suite.Run("NewWithConstructor", func(t *testing.T) {
+		tr := reflection.NewTypeRegistry()
+		assert.False(t, tr.HasConstructor("Point"))
+		require.NoError(t, tr.RegisterConstructor("Point", newPoint))
+		assert.True(t, tr.HasConstructor("Point"))
+
+		val, err := tr.New("Point", 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, point{X: 1, Y: 2}, val)
+	})
+
+	suite.Run("MakeSliceAndMap", func(t *testing.T) {
+		tr := reflection.NewTypeRegistry()
+
+		slice, err := tr.MakeSlice("int", 3)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 0, 0}, slice)
+
+		m, err := tr.MakeMap("string", "float64")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{}, m)
+	})
+
+	suite.Run("IsInstance", func(t *testing.T) {
+		tr := reflection.NewTypeRegistry()
+		require.NoError(t, tr.Register("Reader", (*io.Reader)(nil)))
+
+		ok, err := tr.IsInstance("Reader", strings.NewReader(""))
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = tr.IsInstance("string", 10)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
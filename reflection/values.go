@@ -0,0 +1,125 @@
+package reflection
+
+import (
+	"reflect"
+
+	"github.com/spy16/parens/value"
+)
+
+// convertIdent converts keywords and symbols to their string form (e.g.
+// ":name") when a string is expected.
+func convertIdent(v interface{}, expected reflect.Type) (reflect.Value, bool) {
+	if expected.Kind() != reflect.String {
+		return reflect.Value{}, false
+	}
+
+	switch id := v.(type) {
+	case value.Keyword:
+		return reflect.ValueOf(id.String()).Convert(expected), true
+
+	case value.Symbol:
+		return reflect.ValueOf(id.String()).Convert(expected), true
+	}
+
+	return reflect.Value{}, false
+}
+
+// convertCollection converts the runtime collection values to the Go
+// collection type expected (e.g. *value.Map to map[string]int, *value.Vector
+// to []int). Keys and items are converted the same way as the arguments.
+func convertCollection(v interface{}, expected reflect.Type) (reflect.Value, bool) {
+	switch coll := v.(type) {
+	case *value.Map:
+		if expected.Kind() != reflect.Map {
+			return reflect.Value{}, false
+		}
+
+		keyConv, valConv := newConverter(expected.Key()), newConverter(expected.Elem())
+		res := reflect.MakeMapWithSize(expected, coll.Count())
+		ok := true
+		coll.Range(func(key, val interface{}) bool {
+			k, err := keyConv(key)
+			if err != nil {
+				ok = false
+				return false
+			}
+
+			v, err := valConv(val)
+			if err != nil {
+				ok = false
+				return false
+			}
+
+			res.SetMapIndex(k, v)
+			return true
+		})
+		return res, ok
+
+	case *value.Vector:
+		return convertItems(coll.Slice(), expected)
+
+	case *value.Set:
+		if expected.Kind() == reflect.Map {
+			return convertSetItems(coll.Items(), expected)
+		}
+		return convertItems(coll.Items(), expected)
+	}
+
+	return reflect.Value{}, false
+}
+
+// convertItems converts the items to the slice or array type expected.
+// Arrays must have exactly as many elements as there are items.
+func convertItems(items []interface{}, expected reflect.Type) (reflect.Value, bool) {
+	var res reflect.Value
+	switch expected.Kind() {
+	case reflect.Slice:
+		res = reflect.MakeSlice(expected, len(items), len(items))
+
+	case reflect.Array:
+		if expected.Len() != len(items) {
+			return reflect.Value{}, false
+		}
+		res = reflect.New(expected).Elem()
+
+	default:
+		return reflect.Value{}, false
+	}
+
+	conv := newConverter(expected.Elem())
+	for i, item := range items {
+		rv, err := conv(item)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		res.Index(i).Set(rv)
+	}
+	return res, true
+}
+
+// convertSetItems converts the items to a map[T]struct{} or map[T]bool
+// with the items as keys.
+func convertSetItems(items []interface{}, expected reflect.Type) (reflect.Value, bool) {
+	var member reflect.Value
+	switch elem := expected.Elem(); {
+	case elem.Kind() == reflect.Bool:
+		member = reflect.ValueOf(true).Convert(elem)
+
+	case elem.Kind() == reflect.Struct && elem.NumField() == 0:
+		member = reflect.New(elem).Elem()
+
+	default:
+		return reflect.Value{}, false
+	}
+
+	conv := newConverter(expected.Key())
+	res := reflect.MakeMapWithSize(expected, len(items))
+	for _, item := range items {
+		rv, err := conv(item)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		res.SetMapIndex(rv, member)
+	}
+	return res, true
+}
@@ -2,20 +2,22 @@ package parens
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
 
 	"github.com/chzyer/readline"
+	"github.com/spy16/parens/parser"
 )
 
 // NewREPL initializes a REPL session with given evaluator.
 func NewREPL(exec Executor) (*REPL, error) {
-	ins, err := readline.New("> ")
+	ins, err := readline.New(primaryPrompt)
 	if err != nil {
 		return nil, err
 	}
-	pr := &prompter{ins: ins}
+	pr := newPrompter(ins)
 
 	return &REPL{
 		Exec:     exec,
@@ -85,32 +87,77 @@ type ReadInFunc func() (string, error)
 // WriteOutFunc implementation is used by the REPL to write result.
 type WriteOutFunc func(res interface{}, err error)
 
+// prompter reads complete forms from the terminal. Lines are read until a
+// complete form is available which allows forms to span multiple lines.
 type prompter struct {
-	ins *readline.Instance
+	ins    *readline.Instance
+	lines  *lineReader
+	reader *parser.Reader
+}
+
+func newPrompter(ins *readline.Instance) *prompter {
+	lines := &lineReader{ins: ins}
+	return &prompter{
+		ins:    ins,
+		lines:  lines,
+		reader: parser.NewReader("<REPL>", lines),
+	}
 }
 
 func (pr *prompter) readIn() (string, error) {
-	src, err := pr.ins.Readline()
-	if err != nil {
-		if err == readline.ErrInterrupt {
-			return "", io.EOF
-		}
-		return "", err
+	pr.lines.continued = false
+	pr.ins.SetPrompt(primaryPrompt)
+
+	src, err := pr.reader.ReadSource()
+	if err == errInterrupted {
+		// discard the partially read form.
+		return "", nil
 	}
+	return src, err
+}
 
-	// multiline source
-	if strings.HasSuffix(src, "\\\n") {
-		nl, err := pr.readIn()
-		return strings.Trim(src, "\\\n") + "\n" + nl, err
+// lineReader implements io.Reader by reading lines from the terminal as
+// required. Prompt is switched to the continuation prompt after the first
+// line of a form.
+type lineReader struct {
+	ins       *readline.Instance
+	line      []byte
+	continued bool
+}
+
+func (lr *lineReader) Read(p []byte) (int, error) {
+	if len(lr.line) == 0 {
+		line, err := lr.ins.Readline()
+		if err != nil {
+			if err != readline.ErrInterrupt {
+				return 0, err
+			} else if !lr.continued {
+				return 0, io.EOF
+			}
+			return 0, errInterrupted
+		}
+
+		lr.continued = true
+		lr.ins.SetPrompt(continuationPrompt)
+		lr.line = []byte(line + "\n")
 	}
 
-	return strings.TrimSpace(src), nil
+	n := copy(p, lr.line)
+	lr.line = lr.line[n:]
+	return n, nil
 }
 
+const (
+	primaryPrompt      = "> "
+	continuationPrompt = "| "
+)
+
+var errInterrupted = errors.New("interrupted")
+
 func (pr *prompter) writeOut(v interface{}, err error) {
 	if err != nil {
 		pr.ins.Write([]byte(fmt.Sprintf("error: %s\n", err)))
 		return
 	}
-	pr.ins.Write([]byte(formatResult(v) + "\n"))
+	pr.ins.Write([]byte(parser.Repr(v) + "\n"))
 }
@@ -70,6 +70,15 @@ func TestScope_Get(suite *testing.T) {
 		assert.Equal(t, "hello world", val)
 	})
 
+	suite.Run("BoundToNil", func(t *testing.T) {
+		scope := parens.NewScope(nil)
+		scope.Bind("nothing", nil)
+
+		val, err := scope.Get("nothing")
+		assert.NoError(t, err)
+		assert.Nil(t, val)
+	})
+
 	suite.Run("Pointer", func(t *testing.T) {
 		actualValue := "hello"
 
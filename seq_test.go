@@ -0,0 +1,121 @@
+package parens_test
+
+import (
+	"testing"
+
+	"github.com/spy16/parens"
+	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_Seqs(suite *testing.T) {
+	suite.Parallel()
+
+	cases := map[string]string{
+		`(range 5)`:                    `(0 1 2 3 4)`,
+		`(range 2 5)`:                  `(2 3 4)`,
+		`(range 10 0 -3)`:              `(10 7 4 1)`,
+		`(range 0 1 0.25)`:             `(0 0.25 0.5 0.75)`,
+		`(range 3 3)`:                  `()`,
+		`(take 3 (range))`:             `(0 1 2)`,
+		`(take 3 (drop 1000 (range)))`: `(1000 1001 1002)`,
+		`(map #(* % %) [1 2 3])`:       `(1 4 9)`,
+		`(map #(+ % 1) '(1 2))`:        `(2 3)`,
+		`(map first {:a 1 :b 2})`:      `(:a :b)`,
+		`(filter #(> % 2) [1 2 3 4])`:  `(3 4)`,
+		`(take 3 (filter #(== 0 (mod % 7)) (range 1 1000000000)))`: `(7 14 21)`,
+		`(take 4 (iterate #(* 2 %) 1))`:                            `(1 2 4 8)`,
+		`(map #(do %) "ab")`:                                       `(\a \b)`,
+		`(seq [])`:                                                 `nil`,
+		`(seq [1 2])`:                                              `(1 2)`,
+		`(first (drop 2 (range)))`:                                 `2`,
+		`(rest (take 3 (range)))`:                                  `(1 2)`,
+		`(vec (take 3 (range)))`:                                   `[0 1 2]`,
+		`(set (map #(mod % 3) (range 10)))`:                        `#{0 1 2}`,
+		`(apply + (range 5))`:                                      `10`,
+		`(== (range 3) '(0 1 2))`:                                  `true`,
+		`(== (range 3) [0 1 2])`:                                   `false`,
+		`(cons -1 (take 2 (range)))`:                               `(-1 0 1)`,
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			res, err := executeWithStdlib(t, src)
+			require.NoError(t, err)
+			assert.Equal(t, expected, parser.Repr(res))
+		})
+	}
+
+	suite.Run("LazySeq", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `
+(do
+  (defn ints [n] (lazy-seq (cons n (ints (+ n 1)))))
+  (take 5 (map #(* 10 %) (ints 1))))`)
+		require.NoError(t, err)
+		assert.Equal(t, `(10 20 30 40 50)`, parser.Repr(res))
+	})
+
+	suite.Run("LazySeqEnd", func(t *testing.T) {
+		res, err := executeWithStdlib(t, `
+(do
+  (defn upto [n max] (lazy-seq (cond ((< n max) (cons n (upto (+ n 1) max))))))
+  (upto 0 3))`)
+		require.NoError(t, err)
+		assert.Equal(t, `(0 1 2)`, parser.Repr(res))
+	})
+
+	suite.Run("Laziness", func(t *testing.T) {
+		calls := 0
+		scope := parens.NewScope(nil)
+		require.NoError(t, stdlib.RegisterAll(scope))
+		scope.Bind("trace", func(n int64) int64 {
+			calls++
+			return n
+		})
+
+		res, err := parens.New(scope).Execute(`(take 2 (map trace (range)))`)
+		require.NoError(t, err)
+		assert.Equal(t, 0, calls)
+
+		assert.Equal(t, `(0 1)`, parser.Repr(res))
+		assert.Equal(t, 2, calls)
+	})
+
+	suite.Run("GoValues", func(t *testing.T) {
+		ch := make(chan string, 2)
+		ch <- "a"
+		ch <- "b"
+		close(ch)
+
+		scope := parens.NewScope(nil)
+		require.NoError(t, stdlib.RegisterAll(scope))
+		scope.Bind("ch", ch)
+		scope.Bind("nums", []int{1, 2, 3})
+		scope.Bind("naturals", func(yield func(int) bool) {
+			for i := 0; yield(i); i++ {
+			}
+		})
+
+		res, err := parens.New(scope).Execute(`[(vec ch) (map #(* % 2) nums) (take 3 naturals)]`)
+		require.NoError(t, err)
+		assert.Equal(t, `[["a" "b"] (2 4 6) (0 1 2)]`, parser.Repr(res))
+	})
+
+	suite.Run("Errors", func(t *testing.T) {
+		for _, src := range []string{
+			`(map #(+ % 1))`,
+			`(map #(+ % 1) 10)`,
+			`(take "a" [1])`,
+			`(range "a")`,
+			`(range 1 2 3 4)`,
+			`(vec (map #(+ % "x") [1]))`,
+			`(vec (lazy-seq 10))`,
+		} {
+			_, err := executeWithStdlib(t, src)
+			assert.Error(t, err, src)
+		}
+	})
+}
@@ -0,0 +1,835 @@
+package stdlib
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/reflection"
+	"github.com/spy16/parens/value"
+)
+
+var collections = []mapEntry{
+	entry("reduce", parser.ScopedFunc(reduce),
+		"Calls f with init (or the first item) and the next item, then with the result and the next item and so on. Returns the final result",
+		"Usage: (reduce f coll) or (reduce f init coll)",
+		"Example: (reduce + 0 [1 2 3]) => 6",
+	),
+	entry("into", parser.NativeFunc(into),
+		"Returns the collection with all the items of the other collection added using conj",
+		"Usage: (into to from)",
+		"Example: (into {} [[:a 1]]) => {:a 1}",
+	),
+	entry("assoc", parser.NativeFunc(assoc),
+		"Returns the map (or vector/Go slice with integer keys) with the keys set to the values",
+		"Usage: (assoc coll key1 val1 key2 val2 ...)",
+	),
+	entry("dissoc", parser.NativeFunc(dissoc),
+		"Returns the map without the keys",
+		"Usage: (dissoc map key1 key2 ...)",
+	),
+	entry("get", parser.NativeFunc(get),
+		"Returns the value for the key (or index) in the collection, default or nil if not found",
+		"Usage: (get coll key) or (get coll key default)",
+	),
+	entry("get-in", parser.NativeFunc(getIn),
+		"Returns the value in nested collections by looking up each of the keys in turn, default or nil if not found",
+		"Usage: (get-in coll [key1 key2 ...]) or (get-in coll [key1 key2 ...] default)",
+		"Example: (get-in {:a [1 2]} [:a 1]) => 2",
+	),
+	entry("update-in", parser.ScopedFunc(updateIn),
+		"Returns the nested collections with the value at the keys replaced by the result of calling f with the value and args. Missing levels are created as maps",
+		"Usage: (update-in coll [key1 key2 ...] f arg1 arg2 ...)",
+		"Example: (update-in {:a {:b 1}} [:a :b] + 10) => {:a {:b 11}}",
+	),
+	entry("keys", parser.NativeFunc(keys),
+		"Returns a list of the keys of the map",
+		"Usage: (keys map)",
+	),
+	entry("vals", parser.NativeFunc(vals),
+		"Returns a list of the values of the map",
+		"Usage: (vals map)",
+	),
+	entry("count", parser.NativeFunc(count),
+		"Returns the number of items in the collection",
+		"Usage: (count coll)",
+	),
+	entry("nth", parser.NativeFunc(nth),
+		"Returns the item at the index in the collection. Fails if the index is out of bounds and no default is given",
+		"Usage: (nth coll index) or (nth coll index default)",
+	),
+	entry("concat", parser.NativeFunc(concat),
+		"Returns a lazy sequence of the items of all the collections one after another",
+		"Usage: (concat coll1 coll2 ...)",
+	),
+	entry("sort", parser.ScopedFunc(sortFn),
+		"Returns a list of the items of the collection in ascending order. The comparator may return a boolean (true if less) or a number (negative, zero or positive)",
+		"Usage: (sort coll) or (sort comparator coll)",
+		"Example: (sort > [1 3 2]) => (3 2 1)",
+	),
+	entry("sort-by", parser.ScopedFunc(sortBy),
+		"Returns a list of the items of the collection in ascending order of (keyfn item)",
+		"Usage: (sort-by keyfn coll) or (sort-by keyfn comparator coll)",
+		"Example: (sort-by count [\"ab\" \"c\"]) => (\"c\" \"ab\")",
+	),
+	entry("group-by", parser.ScopedFunc(groupBy),
+		"Returns a map from the results of calling f with the items of the collection to vectors of the items",
+		"Usage: (group-by f coll)",
+	),
+	entry("frequencies", parser.NativeFunc(frequencies),
+		"Returns a map from the distinct items of the collection to the number of times they appear",
+		"Usage: (frequencies coll)",
+	),
+	entry("partition", parser.NativeFunc(partition),
+		"Returns a lazy sequence of lists of n items each, starting step (n by default) items apart. Items at the end which do not fill a list are dropped",
+		"Usage: (partition n coll) or (partition n step coll)",
+		"Example: (partition 2 [1 2 3 4 5]) => ((1 2) (3 4))",
+	),
+	entry("distinct", parser.NativeFunc(distinct),
+		"Returns a lazy sequence of the items of the collection without duplicates",
+		"Usage: (distinct coll)",
+	),
+	entry("zipmap", parser.NativeFunc(zipmap),
+		"Returns a map with the keys mapped to the corresponding values",
+		"Usage: (zipmap keys vals)",
+		"Example: (zipmap [:a :b] [1 2]) => {:a 1 :b 2}",
+	),
+}
+
+func reduce(scope parser.Scope, args ...interface{}) (interface{}, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("2 or 3 arguments required, got %d", len(args))
+	}
+
+	fn := args[0]
+	s, err := value.ToSeq(args[len(args)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	var acc interface{}
+	if len(args) == 3 {
+		acc = args[1]
+	} else {
+		first, rest, ok, err := s.Uncons()
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return parser.Call(scope, fn)
+		}
+		acc, s = first, rest
+	}
+
+	for {
+		item, rest, ok, err := s.Uncons()
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return acc, nil
+		}
+
+		if acc, err = parser.Call(scope, fn, acc, item); err != nil {
+			return nil, err
+		}
+		s = rest
+	}
+}
+
+func into(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("exactly 2 arguments required, got %d", len(args))
+	}
+
+	items, err := toItems(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return conj(append([]interface{}{args[0]}, items...)...)
+}
+
+// conjEntries adds the [key value] vector entries to the map. Go maps are
+// modified in place.
+func conjEntries(m interface{}, entries []interface{}) (interface{}, error) {
+	for _, item := range entries {
+		entry, ok := item.(*value.Vector)
+		if !ok || entry.Count() != 2 {
+			return nil, fmt.Errorf("map entries must be [key value] vectors, not '%s'", parser.Repr(item))
+		}
+
+		key, _ := entry.Nth(0)
+		val, _ := entry.Nth(1)
+
+		var err error
+		if m, err = assocInPlace(m, key, val); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func assoc(args ...interface{}) (interface{}, error) {
+	if len(args) < 3 || len(args)%2 == 0 {
+		return nil, fmt.Errorf("collection and even number of keys and values required, got %d arguments", len(args))
+	}
+
+	coll := args[0]
+	for i := 1; i < len(args); i += 2 {
+		var err error
+		if coll, err = assocKey(coll, args[i], args[i+1]); err != nil {
+			return nil, err
+		}
+	}
+	return coll, nil
+}
+
+func dissoc(args ...interface{}) (interface{}, error) {
+	if len(args) == 0 {
+		return nil, errors.New("at-least 1 argument required, got 0")
+	}
+
+	coll, keys := args[0], args[1:]
+	switch c := coll.(type) {
+	case nil:
+		return nil, nil
+
+	case *value.Map:
+		for _, key := range keys {
+			c = c.Dissoc(key)
+		}
+		return c, nil
+	}
+
+	rv := reflect.ValueOf(coll)
+	if rv.Kind() != reflect.Map {
+		return nil, fmt.Errorf("cannot dissoc from value of type '%s'", reflect.TypeOf(coll))
+	}
+
+	res := copyGoMap(rv)
+	for _, key := range keys {
+		if k, err := reflection.Convert(key, rv.Type().Key()); err == nil {
+			res.SetMapIndex(k, reflect.Value{})
+		}
+	}
+	return res.Interface(), nil
+}
+
+func get(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("2 or 3 arguments required, got %d", len(args))
+	}
+
+	if val, found := lookup(args[0], args[1]); found {
+		return val, nil
+	} else if len(args) == 3 {
+		return args[2], nil
+	}
+	return nil, nil
+}
+
+func getIn(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("2 or 3 arguments required, got %d", len(args))
+	}
+
+	keys, err := toItems(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	val := args[0]
+	for _, key := range keys {
+		var found bool
+		if val, found = lookup(val, key); !found {
+			if len(args) == 3 {
+				return args[2], nil
+			}
+			return nil, nil
+		}
+	}
+	return val, nil
+}
+
+func updateIn(scope parser.Scope, args ...interface{}) (interface{}, error) {
+	if len(args) < 3 {
+		return nil, fmt.Errorf("at-least 3 arguments required, got %d", len(args))
+	}
+
+	keys, err := toItems(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	fn, fnArgs := args[2], args[3:]
+
+	var update func(coll interface{}, keys []interface{}) (interface{}, error)
+	update = func(coll interface{}, keys []interface{}) (interface{}, error) {
+		if len(keys) == 0 {
+			return parser.Call(scope, fn, append([]interface{}{coll}, fnArgs...)...)
+		}
+
+		child, _ := lookup(coll, keys[0])
+		updated, err := update(child, keys[1:])
+		if err != nil {
+			return nil, err
+		}
+		return assocKey(coll, keys[0], updated)
+	}
+
+	return update(args[0], keys)
+}
+
+func keys(args ...interface{}) (interface{}, error) {
+	return mapEntries("keys", 0, args)
+}
+
+func vals(args ...interface{}) (interface{}, error) {
+	return mapEntries("vals", 1, args)
+}
+
+func count(args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("exactly 1 argument required, got %d", len(args))
+	}
+
+	switch coll := args[0].(type) {
+	case nil:
+		return int64(0), nil
+
+	case string:
+		return int64(utf8.RuneCountInString(coll)), nil
+
+	case interface{ Count() int }:
+		return int64(coll.Count()), nil
+
+	case value.Seq:
+		n := int64(0)
+		for {
+			_, rest, ok, err := coll.Uncons()
+			if err != nil {
+				return nil, err
+			} else if !ok {
+				return n, nil
+			}
+			coll, n = rest, n+1
+		}
+	}
+
+	rv := reflect.ValueOf(args[0])
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return int64(rv.Len()), nil
+	}
+
+	return nil, fmt.Errorf("cannot count value of type '%s'", reflect.TypeOf(args[0]))
+}
+
+func nth(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("2 or 3 arguments required, got %d", len(args))
+	}
+
+	i, err := toIndex(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if item, found, err := nthItem(args[0], i); err != nil || found {
+		return item, err
+	} else if len(args) == 3 {
+		return args[2], nil
+	}
+	return nil, fmt.Errorf("index %d out of bounds", i)
+}
+
+func concat(args ...interface{}) (interface{}, error) {
+	seqs, err := toSeqs(args)
+	if err != nil {
+		return nil, err
+	}
+	return value.ConcatSeq(seqs...), nil
+}
+
+func sortFn(scope parser.Scope, args ...interface{}) (interface{}, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, fmt.Errorf("1 or 2 arguments required, got %d", len(args))
+	}
+
+	items, err := toItems(args[len(args)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	cmp := comparator(scope, args[:len(args)-1])
+	if err := sortItems(items, items, cmp); err != nil {
+		return nil, err
+	}
+	return value.NewList(items...), nil
+}
+
+func sortBy(scope parser.Scope, args ...interface{}) (interface{}, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("2 or 3 arguments required, got %d", len(args))
+	}
+
+	items, err := toItems(args[len(args)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]interface{}, len(items))
+	for i, item := range items {
+		if keys[i], err = parser.Call(scope, args[0], item); err != nil {
+			return nil, err
+		}
+	}
+
+	cmp := comparator(scope, args[1:len(args)-1])
+	if err := sortItems(items, keys, cmp); err != nil {
+		return nil, err
+	}
+	return value.NewList(items...), nil
+}
+
+func groupBy(scope parser.Scope, args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("exactly 2 arguments required, got %d", len(args))
+	}
+
+	items, err := toItems(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	res, _ := value.NewMap()
+	for _, item := range items {
+		key, err := parser.Call(scope, args[0], item)
+		if err != nil {
+			return nil, err
+		}
+
+		group, _ := res.Get(key)
+		vec, _ := group.(*value.Vector)
+		if res, err = res.Assoc(key, vec.Conj(item)); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func frequencies(args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("exactly 1 argument required, got %d", len(args))
+	}
+
+	items, err := toItems(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	res, _ := value.NewMap()
+	for _, item := range items {
+		n, _ := res.Get(item)
+		count, _ := n.(int64)
+		if res, err = res.Assoc(item, count+1); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func partition(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, fmt.Errorf("2 or 3 arguments required, got %d", len(args))
+	}
+
+	sizes := make([]int, len(args)-1)
+	for i, arg := range args[:len(args)-1] {
+		n, ok := arg.(int64)
+		if !ok || n <= 0 {
+			return nil, fmt.Errorf("argument %d must be a positive integer, not '%s'", i+1, parser.Repr(arg))
+		}
+		sizes[i] = int(n)
+	}
+
+	s, err := value.ToSeq(args[len(args)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	n, step := sizes[0], sizes[len(sizes)-1]
+	return value.PartitionSeq(n, step, s), nil
+}
+
+func distinct(args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("exactly 1 argument required, got %d", len(args))
+	}
+
+	s, err := value.ToSeq(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return value.DistinctSeq(s), nil
+}
+
+func zipmap(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("exactly 2 arguments required, got %d", len(args))
+	}
+
+	seqs, err := toSeqs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := value.SeqItems(value.ZipSeq(seqs...))
+	if err != nil {
+		return nil, err
+	}
+
+	res, _ := value.NewMap()
+	return conj(append([]interface{}{res}, entries...)...)
+}
+
+// toSeqs converts all the collections to sequences.
+func toSeqs(colls []interface{}) ([]value.Seq, error) {
+	seqs := make([]value.Seq, len(colls))
+	for i, coll := range colls {
+		var err error
+		if seqs[i], err = value.ToSeq(coll); err != nil {
+			return nil, err
+		}
+	}
+	return seqs, nil
+}
+
+// isTruthy calls pred with the args and returns the truthiness of the
+// result.
+func isTruthy(scope parser.Scope, pred interface{}, args ...interface{}) (bool, error) {
+	res, err := parser.Call(scope, pred, args...)
+	if err != nil {
+		return false, err
+	}
+	return !Not(res), nil
+}
+
+// toIndex returns the index passed as an integer argument.
+func toIndex(v interface{}) (int, error) {
+	i, ok := v.(int64)
+	if !ok {
+		return 0, fmt.Errorf("index must be an integer, not '%s'", reflect.TypeOf(v))
+	}
+	return int(i), nil
+}
+
+// lookup returns the value for the key in maps, the item at the index in
+// vectors and Go slices and the key itself if it is contained in sets.
+func lookup(coll, key interface{}) (interface{}, bool) {
+	switch c := coll.(type) {
+	case nil:
+		return nil, false
+
+	case *value.Map:
+		return c.Get(key)
+
+	case *value.Vector:
+		if i, ok := key.(int64); ok {
+			return c.Nth(int(i))
+		}
+		return nil, false
+
+	case *value.Set:
+		return key, c.Contains(key)
+	}
+
+	rv := reflect.ValueOf(coll)
+	switch rv.Kind() {
+	case reflect.Map:
+		k, err := reflection.Convert(key, rv.Type().Key())
+		if err != nil {
+			return nil, false
+		}
+
+		if v := rv.MapIndex(k); v.IsValid() {
+			return v.Interface(), true
+		}
+
+	case reflect.Slice, reflect.Array:
+		if i, ok := key.(int64); ok && i >= 0 && int(i) < rv.Len() {
+			return rv.Index(int(i)).Interface(), true
+		}
+	}
+
+	return nil, false
+}
+
+// nthItem returns the item at the index in the collection. Sequences are
+// realized only till the index.
+func nthItem(coll interface{}, i int) (interface{}, bool, error) {
+	if i < 0 {
+		return nil, false, nil
+	}
+
+	switch c := coll.(type) {
+	case *value.Vector:
+		item, found := c.Nth(i)
+		return item, found, nil
+
+	case string:
+		// strings are sequences of runes (see value.ToSeq)
+
+	default:
+		rv := reflect.ValueOf(coll)
+		if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+			if i >= rv.Len() {
+				return nil, false, nil
+			}
+			return rv.Index(i).Interface(), true, nil
+		}
+	}
+
+	s, err := value.ToSeq(coll)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for ; ; i-- {
+		item, rest, ok, err := s.Uncons()
+		if err != nil || !ok {
+			return nil, false, err
+		} else if i == 0 {
+			return item, true, nil
+		}
+		s = rest
+	}
+}
+
+// assocKey returns the collection with the key set to the value. The
+// collection is not modified. nil results in a new map.
+func assocKey(coll, key, val interface{}) (interface{}, error) {
+	if rv := reflect.ValueOf(coll); rv.Kind() == reflect.Map {
+		coll = copyGoMap(rv).Interface()
+	}
+	return assocInPlace(coll, key, val)
+}
+
+// assocInPlace is like assocKey but Go maps are modified in place.
+func assocInPlace(coll, key, val interface{}) (interface{}, error) {
+	switch c := coll.(type) {
+	case nil:
+		return value.NewMap(key, val)
+
+	case *value.Map:
+		return c.Assoc(key, val)
+
+	case *value.Vector:
+		i, err := toIndex(key)
+		if err != nil {
+			return nil, err
+		}
+		return c.Assoc(i, val)
+	}
+
+	rv := reflect.ValueOf(coll)
+	switch rv.Kind() {
+	case reflect.Map:
+		k, err := reflection.Convert(key, rv.Type().Key())
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := reflection.Convert(val, rv.Type().Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		rv.SetMapIndex(k, v)
+		return coll, nil
+
+	case reflect.Slice:
+		i, err := toIndex(key)
+		if err != nil {
+			return nil, err
+		} else if i < 0 || i > rv.Len() {
+			return nil, fmt.Errorf("index %d out of bounds for slice of size %d", i, rv.Len())
+		}
+
+		v, err := reflection.Convert(val, rv.Type().Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		res := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len()+1)
+		reflect.Copy(res, rv)
+		if i == rv.Len() {
+			return reflect.Append(res, v).Interface(), nil
+		}
+		res.Index(i).Set(v)
+		return res.Interface(), nil
+	}
+
+	return nil, fmt.Errorf("cannot assoc to value of type '%s'", reflect.TypeOf(coll))
+}
+
+// copyGoMap returns a shallow copy of the Go map.
+func copyGoMap(rv reflect.Value) reflect.Value {
+	res := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		res.SetMapIndex(iter.Key(), iter.Value())
+	}
+	return res
+}
+
+// mapEntries returns a list of either the keys (i=0) or the values (i=1)
+// of the map.
+func mapEntries(fn string, i int, args []interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("exactly 1 argument required, got %d", len(args))
+	}
+
+	var res []interface{}
+	switch m := args[0].(type) {
+	case nil:
+
+	case *value.Map:
+		m.Range(func(key, val interface{}) bool {
+			res = append(res, []interface{}{key, val}[i])
+			return true
+		})
+
+	default:
+		rv := reflect.ValueOf(m)
+		if rv.Kind() != reflect.Map {
+			return nil, fmt.Errorf("cannot get %s of value of type '%s'", fn, reflect.TypeOf(m))
+		}
+
+		iter := rv.MapRange()
+		for iter.Next() {
+			res = append(res, []reflect.Value{iter.Key(), iter.Value()}[i].Interface())
+		}
+	}
+
+	return value.NewList(res...), nil
+}
+
+// comparator returns a comparison function which calls the comparator in
+// the args or uses compareValues if there is none. Comparators may return
+// a boolean (true if a is less than b) or a number (negative, zero or
+// positive). Boolean results are only used to tell whether a is less than
+// b, so false results in 0 without calling the comparator again.
+func comparator(scope parser.Scope, args []interface{}) func(a, b interface{}) (int, error) {
+	if len(args) == 0 {
+		return compareValues
+	}
+
+	cmp := args[0]
+	return func(a, b interface{}) (int, error) {
+		res, err := parser.Call(scope, cmp, a, b)
+		if err != nil {
+			return 0, err
+		}
+
+		if less, ok := res.(bool); ok {
+			if less {
+				return -1, nil
+			}
+			return 0, nil
+		}
+
+		if n, ok := toNumber(res); ok {
+			return compareNumbers(n, int64(0)), nil
+		}
+		return 0, fmt.Errorf("comparator must return a boolean or a number, not '%s'", reflect.TypeOf(res))
+	}
+}
+
+// compareValues orders numbers, strings, characters, keywords, symbols and
+// booleans (false before true) naturally and vectors item by item.
+func compareValues(a, b interface{}) (int, error) {
+	if x, ok := toNumber(a); ok {
+		if y, ok := toNumber(b); ok {
+			return compareNumbers(x, y), nil
+		}
+	}
+
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y), nil
+		}
+
+	case rune:
+		if y, ok := b.(rune); ok {
+			return compareNumbers(int64(x), int64(y)), nil
+		}
+
+	case value.Keyword:
+		if y, ok := b.(value.Keyword); ok {
+			return strings.Compare(x.String(), y.String()), nil
+		}
+
+	case value.Symbol:
+		if y, ok := b.(value.Symbol); ok {
+			return strings.Compare(x.String(), y.String()), nil
+		}
+
+	case bool:
+		if y, ok := b.(bool); ok {
+			if x == y {
+				return 0, nil
+			} else if y {
+				return -1, nil
+			}
+			return 1, nil
+		}
+
+	case *value.Vector:
+		if y, ok := b.(*value.Vector); ok {
+			for i := 0; i < x.Count() && i < y.Count(); i++ {
+				xi, _ := x.Nth(i)
+				yi, _ := y.Nth(i)
+				if c, err := compareValues(xi, yi); err != nil || c != 0 {
+					return c, err
+				}
+			}
+			return compareNumbers(int64(x.Count()), int64(y.Count())), nil
+		}
+	}
+
+	return 0, fmt.Errorf("cannot compare '%s' and '%s'", reflect.TypeOf(a), reflect.TypeOf(b))
+}
+
+// sortItems sorts the items (stable) by comparing the corresponding keys.
+// keys may be the items themselves.
+func sortItems(items, keys []interface{}, cmp func(a, b interface{}) (int, error)) error {
+	idx := make([]int, len(items))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	var err error
+	sort.SliceStable(idx, func(i, j int) bool {
+		if err != nil {
+			return false
+		}
+
+		var c int
+		c, err = cmp(keys[idx[i]], keys[idx[j]])
+		return c < 0
+	})
+	if err != nil {
+		return err
+	}
+
+	sorted := make([]interface{}, len(items))
+	for i, k := range idx {
+		sorted[i] = items[k]
+	}
+	copy(items, sorted)
+	return nil
+}
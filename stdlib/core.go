@@ -10,6 +10,8 @@ import (
 
 	"github.com/k0kubun/pp"
 	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/reflection"
+	"github.com/spy16/parens/value"
 )
 
 var core = []mapEntry{
@@ -20,8 +22,8 @@ var core = []mapEntry{
 	entry("false", false,
 		"Represents logical false",
 	),
-	entry("nil", false,
-		"Represents logical false. Same as false",
+	entry("nil", nil,
+		"Represents the absence of a value. Logically false",
 	),
 
 	// core macros
@@ -65,6 +67,130 @@ var core = []mapEntry{
 
 	// core functions
 	entry("type", reflect.TypeOf),
+	entry("apply", parser.ScopedFunc(Apply),
+		"Calls the function with the items of the last argument spread as arguments",
+		"Usage: (apply f arg1 arg2 ... [args])",
+		"Example: (apply + 1 [2 3]) is same as (+ 1 2 3)",
+	),
+	entry("keyword", parser.NativeFunc(keyword),
+		"Returns the keyword with the given name and optional namespace",
+		"Usage: (keyword name) or (keyword ns name)",
+		"Example: (keyword \"user\" \"id\") => :user/id",
+	),
+	entry("symbol", parser.NativeFunc(symbol),
+		"Returns the symbol with the given name and optional namespace",
+		"Usage: (symbol name) or (symbol ns name)",
+	),
+	entry("name", parser.NativeFunc(name),
+		"Returns the name of a keyword or symbol without the namespace",
+		"Example: (name :user/id) => \"id\"",
+	),
+	entry("namespace", parser.NativeFunc(namespace),
+		"Returns the namespace of a keyword or symbol, nil if there is none",
+		"Example: (namespace :user/id) => \"user\"",
+	),
+	entry("keyword?", isKeyword,
+		"Returns true if the value is a keyword",
+	),
+	entry("symbol?", isSymbol,
+		"Returns true if the value is a symbol",
+	),
+	entry("list", value.NewList,
+		"Returns a list containing the arguments",
+		"Usage: (list item1 item2 ...)",
+	),
+	entry("cons", parser.NativeFunc(cons),
+		"Returns a list with the item in front of the items of the collection",
+		"Usage: (cons item coll)",
+		"Example: (cons 1 '(2 3)) => (1 2 3)",
+	),
+	entry("first", parser.NativeFunc(first),
+		"Returns the first item of the collection, nil if it is empty",
+		"Usage: (first coll)",
+	),
+	entry("rest", parser.NativeFunc(rest),
+		"Returns a sequence of the items after the first item of the collection",
+		"Usage: (rest coll)",
+	),
+	entry("conj", parser.NativeFunc(conj),
+		"Returns the collection with the items added. Lists add to the front, vectors and Go slices to the end",
+		"Usage: (conj coll item1 item2 ...)",
+		"Example: (conj '(1) 2 3) => (3 2 1)",
+	),
+	entry("list?", isList,
+		"Returns true if the value is a list",
+	),
+	entry("vector", value.NewVector,
+		"Returns a vector containing the arguments",
+		"Usage: (vector item1 item2 ...)",
+	),
+	entry("hash-map", parser.NativeFunc(hashMap),
+		"Returns a map containing the alternating keys and values",
+		"Usage: (hash-map key1 val1 key2 val2 ...)",
+	),
+	entry("hash-set", parser.NativeFunc(hashSet),
+		"Returns a set containing the arguments",
+		"Usage: (hash-set item1 item2 ...)",
+	),
+	entry("vec", parser.NativeFunc(vec),
+		"Returns a vector containing the items of a collection or a Go slice",
+		"Usage: (vec coll)",
+		"Example: (vec (make-slice \"int\" 2)) => [0 0]",
+	),
+	entry("set", parser.NativeFunc(set),
+		"Returns a set containing the distinct items of a collection or a Go slice",
+		"Usage: (set coll)",
+	),
+	entry("vector?", isVector,
+		"Returns true if the value is a vector",
+	),
+	entry("map?", isMap,
+		"Returns true if the value is a map",
+	),
+	entry("set?", isSet,
+		"Returns true if the value is a set",
+	),
+}
+
+// Apply calls the function passed as the first argument. Arguments in between
+// are passed as is and the items of the last argument, which must be a vector
+// or a list, are spread as individual arguments.
+func Apply(scope parser.Scope, vals ...interface{}) (interface{}, error) {
+	if len(vals) < 2 {
+		return nil, fmt.Errorf("at-least 2 arguments required, got %d", len(vals))
+	}
+
+	if _, isMacro := vals[0].(parser.MacroFunc); isMacro {
+		return nil, errors.New("macros cannot be applied")
+	}
+
+	spread, err := toArgs(vals[len(vals)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]interface{}, 0, len(vals)-2+len(spread))
+	args = append(args, vals[1:len(vals)-1]...)
+	args = append(args, spread...)
+
+	return parser.Call(scope, vals[0], args...)
+}
+
+// toArgs turns a vector, list or any Go slice/array into a list of arguments.
+func toArgs(val interface{}) ([]interface{}, error) {
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+
+	case *value.Vector, value.Seq:
+		return toItems(v)
+	}
+
+	rVal := reflect.ValueOf(val)
+	if rVal.Kind() != reflect.Slice && rVal.Kind() != reflect.Array {
+		return nil, fmt.Errorf("last argument must be a vector or list, not '%s'", rVal.Type())
+	}
+	return toItems(val)
 }
 
 // ThreadFirst macro appends first evaluation result as first argument of next function
@@ -192,9 +318,9 @@ func Lambda(scope parser.Scope, _ string, exprs []parser.Expr) (interface{}, err
 		params = append(params, sym.Symbol)
 	}
 
-	lambdaFunc := func(args ...interface{}) interface{} {
+	lambdaFunc := func(args ...interface{}) (interface{}, error) {
 		if len(params) != len(args) {
-			panic(fmt.Errorf("requires %d arguments, got %d", len(params), len(args)))
+			return nil, fmt.Errorf("requires %d arguments, got %d", len(params), len(args))
 		}
 
 		localScope := parens.NewScope(scope)
@@ -202,15 +328,10 @@ func Lambda(scope parser.Scope, _ string, exprs []parser.Expr) (interface{}, err
 			localScope.Bind(params[i], args[i])
 		}
 
-		val, err := Do(localScope, "", exprs[1:])
-		if err != nil {
-			panic(err)
-		}
-
-		return val
+		return Do(localScope, "", exprs[1:])
 	}
 
-	return lambdaFunc, nil
+	return parser.NativeFunc(lambdaFunc), nil
 }
 
 // Do executes all s-exps one by one and returns the result of last evaluation.
@@ -285,6 +406,285 @@ func Global(scope parser.Scope, name string, exprs []parser.Expr) (interface{},
 	return labelInScope(scope.Root(), name, exprs)
 }
 
+func keyword(args ...interface{}) (interface{}, error) {
+	if len(args) == 1 {
+		switch arg := args[0].(type) {
+		case value.Keyword:
+			return arg, nil
+
+		case value.Symbol:
+			return value.NewKeyword(arg.Namespace(), arg.Name()), nil
+
+		case string:
+			return value.ParseKeyword(arg), nil
+		}
+	}
+
+	ns, name, err := identParts(args)
+	if err != nil {
+		return nil, err
+	}
+	return value.NewKeyword(ns, name), nil
+}
+
+func symbol(args ...interface{}) (interface{}, error) {
+	if len(args) == 1 {
+		switch arg := args[0].(type) {
+		case value.Symbol:
+			return arg, nil
+
+		case value.Keyword:
+			return value.NewSymbol(arg.Namespace(), arg.Name()), nil
+
+		case string:
+			return value.ParseSymbol(arg), nil
+		}
+	}
+
+	ns, name, err := identParts(args)
+	if err != nil {
+		return nil, err
+	}
+	return value.NewSymbol(ns, name), nil
+}
+
+// identParts returns the namespace and name passed as arguments to keyword
+// and symbol.
+func identParts(args []interface{}) (ns, name string, err error) {
+	if len(args) == 1 {
+		return "", "", fmt.Errorf("expecting string, keyword or symbol, not '%s'", reflect.TypeOf(args[0]))
+	} else if len(args) != 2 {
+		return "", "", fmt.Errorf("1 or 2 arguments required, got %d", len(args))
+	}
+
+	ns, ok1 := args[0].(string)
+	name, ok2 := args[1].(string)
+	if !ok1 || !ok2 {
+		return "", "", errors.New("namespace and name must be strings")
+	}
+	return ns, name, nil
+}
+
+func name(args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("exactly 1 argument required, got %d", len(args))
+	}
+
+	switch v := args[0].(type) {
+	case value.Keyword:
+		return v.Name(), nil
+
+	case value.Symbol:
+		return v.Name(), nil
+
+	case string:
+		return v, nil
+	}
+	return nil, fmt.Errorf("expecting string, keyword or symbol, not '%s'", reflect.TypeOf(args[0]))
+}
+
+func namespace(args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("exactly 1 argument required, got %d", len(args))
+	}
+
+	var ns string
+	switch v := args[0].(type) {
+	case value.Keyword:
+		ns = v.Namespace()
+
+	case value.Symbol:
+		ns = v.Namespace()
+
+	default:
+		return nil, fmt.Errorf("expecting keyword or symbol, not '%s'", reflect.TypeOf(args[0]))
+	}
+
+	if ns == "" {
+		return nil, nil
+	}
+	return ns, nil
+}
+
+func isKeyword(v interface{}) bool {
+	_, ok := v.(value.Keyword)
+	return ok
+}
+
+func isSymbol(v interface{}) bool {
+	_, ok := v.(value.Symbol)
+	return ok
+}
+
+func hashMap(kvs ...interface{}) (interface{}, error) {
+	return value.NewMap(kvs...)
+}
+
+func hashSet(items ...interface{}) (interface{}, error) {
+	return value.NewSet(items...)
+}
+
+func vec(args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("exactly 1 argument required, got %d", len(args))
+	}
+
+	if v, ok := args[0].(*value.Vector); ok {
+		return v, nil
+	}
+
+	items, err := toItems(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return value.NewVector(items...), nil
+}
+
+func set(args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("exactly 1 argument required, got %d", len(args))
+	}
+
+	if s, ok := args[0].(*value.Set); ok {
+		return s, nil
+	}
+
+	items, err := toItems(args[0])
+	if err != nil {
+		return nil, err
+	}
+	return value.NewSet(items...)
+}
+
+// toItems realizes the items of any collection or sequence (see
+// value.ToSeq).
+func toItems(v interface{}) ([]interface{}, error) {
+	s, err := value.ToSeq(v)
+	if err != nil {
+		return nil, err
+	}
+	return value.SeqItems(s)
+}
+
+func cons(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("exactly 2 arguments required, got %d", len(args))
+	}
+
+	rest, err := value.ToSeq(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return value.SeqCons(args[0], rest), nil
+}
+
+func first(args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("exactly 1 argument required, got %d", len(args))
+	}
+
+	s, err := value.ToSeq(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	item, _, _, err := s.Uncons()
+	return item, err
+}
+
+func rest(args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("exactly 1 argument required, got %d", len(args))
+	}
+
+	s, err := value.ToSeq(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	_, rest, ok, err := s.Uncons()
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return value.NewList(), nil
+	}
+	return rest, nil
+}
+
+func conj(args ...interface{}) (interface{}, error) {
+	if len(args) == 0 {
+		return nil, errors.New("at-least 1 argument required, got 0")
+	}
+
+	coll, items := args[0], args[1:]
+	switch c := coll.(type) {
+	case nil, *value.List:
+		list, _ := coll.(*value.List)
+		for _, item := range items {
+			list = list.Conj(item)
+		}
+		return list, nil
+
+	case *value.Vector:
+		for _, item := range items {
+			c = c.Conj(item)
+		}
+		return c, nil
+
+	case *value.Set:
+		for _, item := range items {
+			var err error
+			if c, err = c.Conj(item); err != nil {
+				return nil, err
+			}
+		}
+		return c, nil
+
+	case *value.Map:
+		return conjEntries(c, items)
+	}
+
+	rv := reflect.ValueOf(coll)
+	switch rv.Kind() {
+	case reflect.Slice:
+		res := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len()+len(items))
+		reflect.Copy(res, rv)
+		for _, item := range items {
+			v, err := reflection.Convert(item, rv.Type().Elem())
+			if err != nil {
+				return nil, err
+			}
+			res = reflect.Append(res, v)
+		}
+		return res.Interface(), nil
+
+	case reflect.Map:
+		return conjEntries(copyGoMap(rv).Interface(), items)
+	}
+
+	return nil, fmt.Errorf("cannot conj to value of type '%s'", reflect.TypeOf(coll))
+}
+
+func isList(v interface{}) bool {
+	_, ok := v.(*value.List)
+	return ok
+}
+
+func isVector(v interface{}) bool {
+	_, ok := v.(*value.Vector)
+	return ok
+}
+
+func isMap(v interface{}) bool {
+	_, ok := v.(*value.Map)
+	return ok
+}
+
+func isSet(v interface{}) bool {
+	_, ok := v.(*value.Set)
+	return ok
+}
+
 // Inspect dumps the exprs in a formatted manner.
 func Inspect(scope parser.Scope, _ string, exprs []parser.Expr) (interface{}, error) {
 	pp.Println(exprs)
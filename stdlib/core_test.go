@@ -0,0 +1,110 @@
+package stdlib_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/spy16/parens"
+	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/reflection"
+	"github.com/spy16/parens/stdlib"
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApply(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("VariadicGoFunc", func(t *testing.T) {
+		res, err := execute(t, `(apply sprint ["a" 1 "b"])`)
+		require.NoError(t, err)
+		assert.Equal(t, "a1b", res)
+	})
+
+	suite.Run("FixedArityGoFunc", func(t *testing.T) {
+		res, err := execute(t, `(apply join ["a" "b"])`)
+		require.NoError(t, err)
+		assert.Equal(t, "ab", res)
+	})
+
+	suite.Run("LeadingArgs", func(t *testing.T) {
+		res, err := execute(t, `(apply + 1 2 [3 4])`)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), res)
+	})
+
+	suite.Run("QuotedList", func(t *testing.T) {
+		res, err := execute(t, `(apply + '(1 2 3))`)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), res)
+	})
+
+	suite.Run("Lambda", func(t *testing.T) {
+		res, err := execute(t, `(apply (lambda [a b] (- a b)) [3 1])`)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), res)
+	})
+
+	suite.Run("ScopedFunc", func(t *testing.T) {
+		res, err := execute(t, `(apply apply [+ [1 2]])`)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), res)
+	})
+
+	suite.Run("WrongArity", func(t *testing.T) {
+		_, err := execute(t, `(apply join ["a"])`)
+		assert.IsType(t, &reflection.ArgCountError{}, err)
+	})
+
+	suite.Run("NotASequence", func(t *testing.T) {
+		_, err := execute(t, `(apply + 1)`)
+		assert.Error(t, err)
+	})
+
+	suite.Run("Macro", func(t *testing.T) {
+		_, err := execute(t, `(apply do [1 2])`)
+		assert.Error(t, err)
+	})
+}
+
+func TestRegister_Modules(suite *testing.T) {
+	suite.Parallel()
+
+	modules := map[string]struct {
+		register func(parser.Scope) error
+		names    []string
+	}{
+		"Core": {register: stdlib.RegisterCore, names: []string{"first", "rest", "conj"}},
+		"Seq":  {register: stdlib.RegisterSeq, names: []string{"map", "filter"}},
+	}
+
+	for name, module := range modules {
+		module := module
+		suite.Run(name, func(t *testing.T) {
+			scope := parens.NewScope(nil)
+			require.NoError(t, module.register(scope))
+			for _, name := range module.names {
+				_, err := scope.Get(name)
+				assert.NoError(t, err, name)
+			}
+		})
+	}
+}
+
+func execute(t *testing.T, src string) (interface{}, error) {
+	scope := parens.NewScope(nil)
+	require.NoError(t, stdlib.RegisterAll(scope))
+	scope.Bind("sprint", fmt.Sprint)
+	scope.Bind("join", func(a, b string) string { return a + b })
+
+	return parens.New(scope).Execute(src)
+}
+
+func mapOf(kvs ...interface{}) *value.Map {
+	m, err := value.NewMap(kvs...)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
@@ -2,8 +2,12 @@ package stdlib
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
+
+	"github.com/spy16/parens/parser"
 )
 
 var io = []mapEntry{
@@ -16,6 +20,20 @@ var io = []mapEntry{
 	entry("printf", printf,
 		"Formats the first string using remaining arguments and prints",
 	),
+	entry("pr", pr,
+		"Prints the arguments in readable form separated by spaces",
+	),
+	entry("prn", prn,
+		"Same as pr but prints a newline at the end",
+	),
+	entry("pr-str", prStr,
+		"Returns the arguments in readable form separated by spaces as a string",
+		"Example: (pr-str \"a\" [1 2])",
+	),
+	entry("read-string", readString,
+		"Reads the first form in the string as data without evaluating it",
+		"Example: (read-string \"[1 :a (+ 1 2)]\")",
+	),
 	entry("read", read,
 		"Reads a line from the console. Throws error if fails",
 		"Usage: (read)",
@@ -34,6 +52,34 @@ func print(args ...interface{}) {
 	fmt.Print(args...)
 }
 
+func pr(args ...interface{}) {
+	fmt.Print(prStr(args...))
+}
+
+func prn(args ...interface{}) {
+	fmt.Println(prStr(args...))
+}
+
+func prStr(args ...interface{}) string {
+	strs := make([]string, len(args))
+	for i, arg := range args {
+		strs[i] = parser.Repr(arg)
+	}
+	return strings.Join(strs, " ")
+}
+
+func readString(src string) interface{} {
+	forms, err := parser.ReadData("<string>", src)
+	if err != nil {
+		panic(err)
+	}
+
+	if len(forms) == 0 {
+		panic(errors.New("no forms in the string"))
+	}
+	return forms[0]
+}
+
 func read() string {
 	reader := bufio.NewReader(os.Stdin)
 	text, err := reader.ReadString('\n')
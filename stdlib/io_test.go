@@ -0,0 +1,55 @@
+package stdlib_test
+
+import (
+	"testing"
+
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrStr(suite *testing.T) {
+	suite.Parallel()
+
+	cases := map[string]string{
+		`(pr-str)`:                   ``,
+		`(pr-str "a\n" \b 1.0)`:      `"a\n" \b 1.0`,
+		`(pr-str [1 "x"] {:a #{2}})`: `[1 "x"] {:a #{2}}`,
+		`(pr-str '(+ 1 2))`:          `(+ 1 2)`,
+		`(pr-str (pr-str "quoted"))`: `"\"quoted\""`,
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			res, err := execute(t, src)
+			require.NoError(t, err)
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestReadString(suite *testing.T) {
+	suite.Parallel()
+
+	cases := map[string]interface{}{
+		`(read-string "42")`:                          int64(42),
+		`(read-string "[1 \"a\"] ignored")`:           value.NewVector(int64(1), "a"),
+		`(eval (read-string "(+ 1 2)"))`:              int64(3),
+		`(read-string (pr-str [1 2.5 "x\n" {:a 1}]))`: value.NewVector(int64(1), 2.5, "x\n", mapOf(value.ParseKeyword(":a"), int64(1))),
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			res, err := execute(t, src)
+			require.NoError(t, err)
+			assert.Equal(t, expected, res)
+		})
+	}
+
+	suite.Run("Empty", func(t *testing.T) {
+		_, err := execute(t, `(read-string "  ")`)
+		assert.Error(t, err)
+	})
+}
@@ -2,7 +2,8 @@ package stdlib
 
 import (
 	"fmt"
-	"reflect"
+
+	"github.com/spy16/parens/value"
 )
 
 var math = []mapEntry{
@@ -19,6 +20,21 @@ var math = []mapEntry{
 	),
 	entry("/", Div,
 		"Returns result of continuously dividing first arg by remaining args",
+		"Dividing integers results in a ratio if they are not divisible",
+	),
+	entry("quot", Quot,
+		"Returns the quotient of dividing first arg by second arg truncated towards zero",
+		"Usage: (quot num div)",
+	),
+	entry("rem", Rem,
+		"Returns the remainder of dividing first arg by second arg",
+		"Result has the same sign as the first arg",
+		"Usage: (rem num div)",
+	),
+	entry("mod", Mod,
+		"Returns the modulus of first arg and second arg",
+		"Result has the same sign as the second arg",
+		"Usage: (mod num div)",
 	),
 	entry(">", Gt,
 		"Returns true if 1st arg is greater than the 2nd",
@@ -34,81 +50,89 @@ var math = []mapEntry{
 	),
 }
 
-// Add returns sum of all the arguments.
-func Add(vals ...float64) float64 {
-	sum := 0.0
-	for _, val := range vals {
-		sum += val
-	}
-
-	return sum
+// Add returns sum of all the arguments. Integers that overflow int64
+// are promoted to *big.Int.
+func Add(args ...interface{}) (interface{}, error) {
+	return reduceNumbers(addOp, int64(0), args)
 }
 
-// Sub returns result of subtracting from left-to-right.
-func Sub(vals ...float64) float64 {
-	if len(vals) == 1 {
-		if vals[0] == 0 {
-			return 0
-		}
-		return -1 * vals[0]
+// Sub returns result of subtracting from left-to-right. If only one
+// argument is passed, returns its negation.
+func Sub(args ...interface{}) (interface{}, error) {
+	if len(args) <= 1 {
+		return reduceNumbers(subOp, int64(0), args)
 	}
 
-	for i := 1; i < len(vals); i++ {
-		vals[i] = -1 * vals[i]
-	}
-
-	return Add(vals...)
+	return reduceNumbers(subOp, nil, args)
 }
 
 // Mul multiplies all numbers.
-func Mul(vals ...float64) float64 {
-	result := 1.0
-	for _, val := range vals {
-		result = result * val
+func Mul(args ...interface{}) (interface{}, error) {
+	return reduceNumbers(mulOp, int64(1), args)
+}
+
+// Div divides from left to right. Division of integers is exact and
+// results in a *big.Rat if they are not divisible.
+func Div(args ...interface{}) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("division requires at least 2 arguments, got %d", len(args))
 	}
 
-	return result
+	return reduceNumbers(divOp, nil, args)
 }
 
-// Div divides from left to right.
-func Div(vals ...float64) float64 {
-	if len(vals) < 2 {
-		panic(fmt.Errorf("division requires at least 2 arguments, got %d", len(vals)))
-	}
+// Quot returns the quotient of division truncated towards zero. The result
+// is an integer for integer and ratio arguments.
+func Quot(args ...interface{}) (interface{}, error) {
+	return binaryNumberOp(quotOp, args)
+}
 
-	result := vals[0]
-	for i := 1; i < len(vals); i++ {
-		result = result / vals[i]
-	}
+// Rem returns the remainder of truncated division.
+func Rem(args ...interface{}) (interface{}, error) {
+	return binaryNumberOp(remOp, args)
+}
 
-	return result
+// Mod returns the modulus of floored division.
+func Mod(args ...interface{}) (interface{}, error) {
+	return binaryNumberOp(modOp, args)
 }
 
 // Gt checks if lval is greater than rval
-func Gt(lval, rval float64) bool {
-	return lval > rval
+func Gt(args ...interface{}) (interface{}, error) {
+	nums, err := toNumberPair(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareNumbers(nums[0], nums[1]) > 0, nil
 }
 
 // Lt checks if lval is lesser than rval
-func Lt(lval, rval float64) bool {
-	return lval < rval
+func Lt(args ...interface{}) (interface{}, error) {
+	nums, err := toNumberPair(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareNumbers(nums[0], nums[1]) < 0, nil
 }
 
-// Eq checks if lval is same as rval
-func Eq(vals ...interface{}) bool {
+// Eq checks if all the values are equal. Numbers are compared by value
+// irrespective of their types (i.e., (== 1 1.0) is true).
+func Eq(vals ...interface{}) (interface{}, error) {
 	if len(vals) <= 1 {
-		return true
+		return true, nil
 	}
 
 	lval := vals[0]
 
 	for i := 1; i < len(vals); i++ {
-		if !reflect.DeepEqual(lval, vals[i]) {
-			return false
+		if !equals(lval, vals[i]) {
+			return false, nil
 		}
 	}
 
-	return true
+	return true, nil
 }
 
 // Not returns true if val is nil or false value and false
@@ -124,3 +148,55 @@ func Not(val interface{}) bool {
 
 	return false
 }
+
+// reduceNumbers applies the op from left to right starting with init. If
+// init is nil, first argument is used as the initial value.
+func reduceNumbers(op numOp, init interface{}, args []interface{}) (interface{}, error) {
+	nums, err := toNumbers(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if init == nil {
+		init, nums = nums[0], nums[1:]
+	}
+
+	result := init
+	for _, num := range nums {
+		result, err = op.apply(result, num)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func binaryNumberOp(op numOp, args []interface{}) (interface{}, error) {
+	nums, err := toNumberPair(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return op.apply(nums[0], nums[1])
+}
+
+func toNumberPair(args []interface{}) ([]interface{}, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("exactly 2 arguments required, got %d", len(args))
+	}
+
+	return toNumbers(args)
+}
+
+// equals compares numbers by value irrespective of their types and
+// everything else using value.Equal.
+func equals(lval, rval interface{}) bool {
+	lnum, lok := toNumber(lval)
+	rnum, rok := toNumber(rval)
+	if lok && rok {
+		return compareNumbers(lnum, rnum) == 0
+	}
+
+	return value.Equal(lval, rval)
+}
@@ -0,0 +1,78 @@
+package stdlib_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMath(suite *testing.T) {
+	suite.Parallel()
+
+	overflow, _ := new(big.Int).SetString("9223372036854775808", 10)
+
+	cases := map[string]interface{}{
+		"(+)":                        int64(0),
+		"(+ 1 2 3)":                  int64(6),
+		"(+ 1 2.5)":                  3.5,
+		"(+ 9223372036854775807 1)":  overflow,
+		"(- 9223372036854775808N 1)": int64(math.MaxInt64),
+		"(- 5)":                      int64(-5),
+		"(- 10 1 2)":                 int64(7),
+		"(- -9223372036854775808 1)": new(big.Int).Neg(big.NewInt(0).Add(overflow, big.NewInt(1))),
+		"(* 2 3 4)":                  int64(24),
+		"(* 2 1.5)":                  3.0,
+		"(* 4294967296 4294967296)":  new(big.Int).Lsh(big.NewInt(1), 64),
+		"(/ 10 2)":                   int64(5),
+		"(/ 1 2)":                    big.NewRat(1, 2),
+		"(/ 1.0 4)":                  0.25,
+		"(+ 1/2 1/2)":                int64(1),
+		"(+ 1/2 0.5)":                1.0,
+		"(quot 7 2)":                 int64(3),
+		"(quot -7 2)":                int64(-3),
+		"(rem -7 2)":                 int64(-1),
+		"(mod -7 2)":                 int64(1),
+		"(mod 7 -2)":                 int64(-1),
+		"(mod 7.5 2)":                1.5,
+		"(quot 3/2 1)":               int64(1),
+		"(quot -7/2 1/2)":            int64(-7),
+		"(quot 7/2 2)":               int64(1),
+		"(rem 3/2 1)":                big.NewRat(1, 2),
+		"(rem -7/2 2)":               big.NewRat(-3, 2),
+		"(rem 3 1/2)":                int64(0),
+		"(mod -3/2 1)":               big.NewRat(1, 2),
+		"(mod 3/2 -1)":               big.NewRat(-1, 2),
+		"(> 2 1.5)":                  true,
+		"(< 1/3 0.5)":                true,
+		"(> 1 99999999999999999999)": false,
+		"(== 1 1.0 1N)":              true,
+		"(== 1 2)":                   false,
+		"(== \"a\" \"a\")":           true,
+		"(== \\a 97)":                false,
+		"(== \\a \\a)":               true,
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			res, err := execute(t, src)
+			require.NoError(t, err)
+			assert.Equal(t, expected, res)
+		})
+	}
+}
+
+func TestMath_Errors(suite *testing.T) {
+	suite.Parallel()
+
+	for _, src := range []string{`(/ 1 0)`, `(quot 1 0)`, `(mod 1 0)`, `(+ 1 "a")`, `(rem 1/2 0)`, `(quot 1/2 0)`, `(> 1)`, `(+ \a 1)`, `(< \a \b)`} {
+		src := src
+		suite.Run(src, func(t *testing.T) {
+			_, err := execute(t, src)
+			assert.Error(t, err)
+		})
+	}
+}
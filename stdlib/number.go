@@ -0,0 +1,374 @@
+package stdlib
+
+import (
+	"errors"
+	"fmt"
+	gomath "math"
+	"math/big"
+	"reflect"
+)
+
+// errDivideByZero is returned when an integer or ratio is divided by zero.
+var errDivideByZero = errors.New("divide by zero")
+
+// numLevel is the position of a number type in the numeric tower. When
+// two numbers of different levels are combined, the one with lower level
+// is promoted to the higher level.
+type numLevel int
+
+const (
+	intLevel numLevel = iota
+	bigIntLevel
+	ratLevel
+	floatLevel
+)
+
+// numOp implements a binary operation for each level of the numeric tower.
+// ints must return false if the result overflows int64, in which case the
+// operation is retried using bigs.
+type numOp struct {
+	ints   func(a, b int64) (int64, bool)
+	bigs   func(a, b *big.Int) (interface{}, error)
+	rats   func(a, b *big.Rat) (interface{}, error)
+	floats func(a, b float64) (interface{}, error)
+}
+
+var (
+	addOp = numOp{
+		ints: func(a, b int64) (int64, bool) {
+			s := a + b
+			return s, (s > a) == (b > 0)
+		},
+		bigs: func(a, b *big.Int) (interface{}, error) {
+			return new(big.Int).Add(a, b), nil
+		},
+		rats: func(a, b *big.Rat) (interface{}, error) {
+			return new(big.Rat).Add(a, b), nil
+		},
+		floats: func(a, b float64) (interface{}, error) {
+			return a + b, nil
+		},
+	}
+
+	subOp = numOp{
+		ints: func(a, b int64) (int64, bool) {
+			d := a - b
+			return d, (d < a) == (b > 0)
+		},
+		bigs: func(a, b *big.Int) (interface{}, error) {
+			return new(big.Int).Sub(a, b), nil
+		},
+		rats: func(a, b *big.Rat) (interface{}, error) {
+			return new(big.Rat).Sub(a, b), nil
+		},
+		floats: func(a, b float64) (interface{}, error) {
+			return a - b, nil
+		},
+	}
+
+	mulOp = numOp{
+		ints: func(a, b int64) (int64, bool) {
+			if a == 0 || b == 0 {
+				return 0, true
+			}
+			p := a * b
+			return p, p/b == a && !(a == -1 && b == gomath.MinInt64) && !(b == -1 && a == gomath.MinInt64)
+		},
+		bigs: func(a, b *big.Int) (interface{}, error) {
+			return new(big.Int).Mul(a, b), nil
+		},
+		rats: func(a, b *big.Rat) (interface{}, error) {
+			return new(big.Rat).Mul(a, b), nil
+		},
+		floats: func(a, b float64) (interface{}, error) {
+			return a * b, nil
+		},
+	}
+
+	// divOp performs exact division. Integers that are not divisible
+	// result in a ratio.
+	divOp = numOp{
+		bigs: func(a, b *big.Int) (interface{}, error) {
+			if b.Sign() == 0 {
+				return nil, errDivideByZero
+			}
+			return new(big.Rat).SetFrac(a, b), nil
+		},
+		rats: func(a, b *big.Rat) (interface{}, error) {
+			if b.Sign() == 0 {
+				return nil, errDivideByZero
+			}
+			return new(big.Rat).Quo(a, b), nil
+		},
+		floats: func(a, b float64) (interface{}, error) {
+			return a / b, nil
+		},
+	}
+
+	// quotOp performs division truncated towards zero.
+	quotOp = numOp{
+		bigs: func(a, b *big.Int) (interface{}, error) {
+			if b.Sign() == 0 {
+				return nil, errDivideByZero
+			}
+			return new(big.Int).Quo(a, b), nil
+		},
+		rats: func(a, b *big.Rat) (interface{}, error) {
+			if b.Sign() == 0 {
+				return nil, errDivideByZero
+			}
+			return quotRat(a, b), nil
+		},
+		floats: func(a, b float64) (interface{}, error) {
+			return gomath.Trunc(a / b), nil
+		},
+	}
+
+	// remOp returns the remainder of truncated division. Result has the
+	// same sign as the dividend.
+	remOp = numOp{
+		bigs: func(a, b *big.Int) (interface{}, error) {
+			if b.Sign() == 0 {
+				return nil, errDivideByZero
+			}
+			return new(big.Int).Rem(a, b), nil
+		},
+		rats: func(a, b *big.Rat) (interface{}, error) {
+			if b.Sign() == 0 {
+				return nil, errDivideByZero
+			}
+			return remRat(a, b), nil
+		},
+		floats: func(a, b float64) (interface{}, error) {
+			return gomath.Mod(a, b), nil
+		},
+	}
+
+	// modOp returns the modulus of floored division. Result has the same
+	// sign as the divisor.
+	modOp = numOp{
+		bigs: func(a, b *big.Int) (interface{}, error) {
+			if b.Sign() == 0 {
+				return nil, errDivideByZero
+			}
+
+			r := new(big.Int).Rem(a, b)
+			if r.Sign() != 0 && r.Sign() != b.Sign() {
+				r.Add(r, b)
+			}
+			return r, nil
+		},
+		rats: func(a, b *big.Rat) (interface{}, error) {
+			if b.Sign() == 0 {
+				return nil, errDivideByZero
+			}
+
+			r := remRat(a, b)
+			if r.Sign() != 0 && r.Sign() != b.Sign() {
+				r.Add(r, b)
+			}
+			return r, nil
+		},
+		floats: func(a, b float64) (interface{}, error) {
+			r := gomath.Mod(a, b)
+			if r != 0 && (r < 0) != (b < 0) {
+				r += b
+			}
+			return r, nil
+		},
+	}
+)
+
+// quotRat returns the quotient of dividing a by b truncated towards zero.
+func quotRat(a, b *big.Rat) *big.Int {
+	q := new(big.Rat).Quo(a, b)
+	return new(big.Int).Quo(q.Num(), q.Denom())
+}
+
+// remRat returns the remainder of truncated division of a by b.
+func remRat(a, b *big.Rat) *big.Rat {
+	q := new(big.Rat).SetInt(quotRat(a, b))
+	return q.Sub(a, q.Mul(q, b))
+}
+
+// apply performs the operation on the numbers after promoting them to the
+// same level. Integer results are returned as int64 whenever they fit.
+func (op numOp) apply(a, b interface{}) (interface{}, error) {
+	level := maxLevel(levelOf(a), levelOf(b))
+
+	switch level {
+	case intLevel:
+		if op.ints != nil {
+			if res, ok := op.ints(a.(int64), b.(int64)); ok {
+				return res, nil
+			}
+		}
+		fallthrough
+
+	case bigIntLevel:
+		if op.bigs == nil {
+			break
+		}
+		res, err := op.bigs(toBigInt(a), toBigInt(b))
+		if err != nil {
+			return nil, err
+		}
+		return normalizeNumber(res), nil
+
+	case ratLevel:
+		if op.rats == nil {
+			break
+		}
+		res, err := op.rats(toRat(a), toRat(b))
+		if err != nil {
+			return nil, err
+		}
+		return normalizeNumber(res), nil
+
+	case floatLevel:
+		return op.floats(toFloat(a), toFloat(b))
+	}
+
+	return nil, fmt.Errorf("operation not supported for '%s' and '%s'", reflect.TypeOf(a), reflect.TypeOf(b))
+}
+
+// compareNumbers returns -1, 0 or +1 depending on whether a is less than,
+// equal to or greater than b.
+func compareNumbers(a, b interface{}) int {
+	switch maxLevel(levelOf(a), levelOf(b)) {
+	case intLevel:
+		x, y := a.(int64), b.(int64)
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+		return 0
+
+	case bigIntLevel:
+		return toBigInt(a).Cmp(toBigInt(b))
+
+	case ratLevel:
+		return toRat(a).Cmp(toRat(b))
+
+	default:
+		x, y := toFloat(a), toFloat(b)
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+		return 0
+	}
+}
+
+// toNumber normalizes any Go number into one of int64, *big.Int, *big.Rat
+// or float64. Runes are characters (e.g. \a) and are not numbers even
+// though rune is an integer type in Go.
+func toNumber(v interface{}) (interface{}, bool) {
+	switch n := v.(type) {
+	case int64, float64, *big.Int, *big.Rat:
+		return n, true
+
+	case rune:
+		return nil, false
+	}
+
+	rVal := reflect.ValueOf(v)
+	switch rVal.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rVal.Int(), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return normalizeNumber(new(big.Int).SetUint64(rVal.Uint())), true
+
+	case reflect.Float32, reflect.Float64:
+		return rVal.Float(), true
+	}
+
+	return nil, false
+}
+
+// toNumbers normalizes all the args using toNumber.
+func toNumbers(args []interface{}) ([]interface{}, error) {
+	nums := make([]interface{}, len(args))
+	for i, arg := range args {
+		num, ok := toNumber(arg)
+		if !ok {
+			return nil, fmt.Errorf("argument %d must be a number, not '%s'", i+1, reflect.TypeOf(arg))
+		}
+		nums[i] = num
+	}
+
+	return nums, nil
+}
+
+// normalizeNumber turns integral big values into int64 if they fit.
+func normalizeNumber(v interface{}) interface{} {
+	switch n := v.(type) {
+	case *big.Int:
+		if n.IsInt64() {
+			return n.Int64()
+		}
+
+	case *big.Rat:
+		if n.IsInt() {
+			return normalizeNumber(new(big.Int).Set(n.Num()))
+		}
+	}
+
+	return v
+}
+
+func levelOf(num interface{}) numLevel {
+	switch num.(type) {
+	case int64:
+		return intLevel
+	case *big.Int:
+		return bigIntLevel
+	case *big.Rat:
+		return ratLevel
+	default:
+		return floatLevel
+	}
+}
+
+func maxLevel(a, b numLevel) numLevel {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func toBigInt(num interface{}) *big.Int {
+	if n, ok := num.(*big.Int); ok {
+		return n
+	}
+	return big.NewInt(num.(int64))
+}
+
+func toRat(num interface{}) *big.Rat {
+	switch n := num.(type) {
+	case *big.Rat:
+		return n
+	case *big.Int:
+		return new(big.Rat).SetInt(n)
+	default:
+		return new(big.Rat).SetInt64(n.(int64))
+	}
+}
+
+func toFloat(num interface{}) float64 {
+	switch n := num.(type) {
+	case float64:
+		return n
+	case int64:
+		return float64(n)
+	case *big.Int:
+		f, _ := new(big.Float).SetInt(n).Float64()
+		return f
+	default:
+		f, _ := n.(*big.Rat).Float64()
+		return f
+	}
+}
@@ -0,0 +1,201 @@
+package stdlib
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/value"
+)
+
+var seqs = []mapEntry{
+	entry("seq", parser.NativeFunc(seq),
+		"Returns a sequence of the items of the collection, nil if it is empty",
+		"Usage: (seq coll)",
+	),
+	entry("lazy-seq", parser.MacroFunc(LazySeq),
+		"Returns a sequence which evaluates the body when the items are first needed",
+		"Usage: (lazy-seq body...)",
+		"Example: (defn ints [n] (lazy-seq (cons n (ints (+ n 1)))))",
+	),
+	entry("map", parser.ScopedFunc(mapFn),
+		"Returns a lazy sequence of the results of calling f with the first items of all the collections, then the second items and so on until any of the collections ends",
+		"Usage: (map f coll1 coll2 ...)",
+		"Example: (map + [1 2] [10 20]) => (11 22)",
+	),
+	entry("filter", parser.ScopedFunc(filter),
+		"Returns a lazy sequence of the items of the collection for which pred returns true",
+		"Usage: (filter pred coll)",
+	),
+	entry("take", parser.NativeFunc(take),
+		"Returns a lazy sequence of the first n items of the collection",
+		"Usage: (take n coll)",
+	),
+	entry("drop", parser.NativeFunc(drop),
+		"Returns a lazy sequence of the items of the collection after the first n items",
+		"Usage: (drop n coll)",
+	),
+	entry("range", parser.NativeFunc(rangeFn),
+		"Returns a lazy sequence of numbers from start (inclusive, 0 by default) to end (exclusive, infinite by default)",
+		"Usage: (range), (range end), (range start end) or (range start end step)",
+		"Example: (range 1 10 2) => (1 3 5 7 9)",
+	),
+	entry("iterate", parser.ScopedFunc(iterate),
+		"Returns an infinite lazy sequence of x, (f x), (f (f x)) etc.",
+		"Usage: (iterate f x)",
+	),
+}
+
+// LazySeq returns a value.LazySeq which evaluates the exprs in the scope
+// when the items are first needed. The result of the last expr must be a
+// collection or nil.
+func LazySeq(scope parser.Scope, _ string, exprs []parser.Expr) (interface{}, error) {
+	return value.NewLazySeq(func() (value.Seq, error) {
+		val, err := Do(scope, "", exprs)
+		if err != nil {
+			return nil, err
+		}
+		return value.ToSeq(val)
+	}), nil
+}
+
+func seq(args ...interface{}) (interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("exactly 1 argument required, got %d", len(args))
+	}
+
+	s, err := value.ToSeq(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, ok, err := s.Uncons(); err != nil || !ok {
+		return nil, err
+	}
+	return s, nil
+}
+
+func mapFn(scope parser.Scope, args ...interface{}) (interface{}, error) {
+	if len(args) < 2 {
+		return nil, fmt.Errorf("at-least 2 arguments required, got %d", len(args))
+	}
+
+	fn := args[0]
+	seqs, err := toSeqs(args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(seqs) == 1 {
+		return value.MapSeq(func(item interface{}) (interface{}, error) {
+			return parser.Call(scope, fn, item)
+		}, seqs[0]), nil
+	}
+
+	return value.MapSeq(func(items interface{}) (interface{}, error) {
+		return parser.Call(scope, fn, items.(*value.Vector).Slice()...)
+	}, value.ZipSeq(seqs...)), nil
+}
+
+func filter(scope parser.Scope, args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("exactly 2 arguments required, got %d", len(args))
+	}
+
+	pred := args[0]
+	s, err := value.ToSeq(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return value.FilterSeq(func(item interface{}) (bool, error) {
+		return isTruthy(scope, pred, item)
+	}, s), nil
+}
+
+func take(args ...interface{}) (interface{}, error) {
+	n, s, err := countAndSeq(args)
+	if err != nil {
+		return nil, err
+	}
+	return value.TakeSeq(n, s), nil
+}
+
+func drop(args ...interface{}) (interface{}, error) {
+	n, s, err := countAndSeq(args)
+	if err != nil {
+		return nil, err
+	}
+	return value.DropSeq(n, s), nil
+}
+
+func rangeFn(args ...interface{}) (interface{}, error) {
+	if len(args) > 3 {
+		return nil, fmt.Errorf("at most 3 arguments allowed, got %d", len(args))
+	}
+
+	nums, err := toNumbers(args)
+	if err != nil {
+		return nil, err
+	}
+
+	start, step := interface{}(int64(0)), interface{}(int64(1))
+	switch len(nums) {
+	case 3:
+		step = nums[2]
+		fallthrough
+
+	case 2:
+		start = nums[0]
+	}
+
+	s := value.IterateSeq(func(x interface{}) (interface{}, error) {
+		return Add(x, step)
+	}, start)
+	if len(nums) == 0 {
+		return s, nil
+	}
+
+	end := nums[0]
+	if len(nums) > 1 {
+		end = nums[1]
+	}
+
+	descending := compareNumbers(step, int64(0)) < 0
+	return value.TakeWhileSeq(func(x interface{}) (bool, error) {
+		if descending {
+			return compareNumbers(x, end) > 0, nil
+		}
+		return compareNumbers(x, end) < 0, nil
+	}, s), nil
+}
+
+func iterate(scope parser.Scope, args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("exactly 2 arguments required, got %d", len(args))
+	}
+
+	fn := args[0]
+	return value.IterateSeq(func(x interface{}) (interface{}, error) {
+		return parser.Call(scope, fn, x)
+	}, args[1]), nil
+}
+
+// countAndSeq returns the count and the sequence of the collection passed
+// as arguments to take and drop.
+func countAndSeq(args []interface{}) (int, value.Seq, error) {
+	if len(args) != 2 {
+		return 0, nil, fmt.Errorf("exactly 2 arguments required, got %d", len(args))
+	}
+
+	n, ok := args[0].(int64)
+	if !ok {
+		return 0, nil, fmt.Errorf("count must be an integer, not '%s'", reflect.TypeOf(args[0]))
+	}
+
+	s, err := value.ToSeq(args[1])
+	if err != nil {
+		return 0, nil, err
+	}
+	return int(n), s, nil
+}
@@ -12,14 +12,35 @@ func RegisterAll(scope parser.Scope) error {
 		RegisterMath,
 		RegisterIO,
 		RegisterSystem,
+		RegisterTypes,
+		RegisterSeq,
+		RegisterCollections,
 	)
 }
 
+// RegisterSeq binds functions for creating and processing (possibly lazy
+// and infinite) sequences into the scope.
+func RegisterSeq(scope parser.Scope) error {
+	return registerList(scope, seqs)
+}
+
+// RegisterCollections binds functions for accessing, updating and
+// transforming collections (including Go slices and maps) into the scope.
+func RegisterCollections(scope parser.Scope) error {
+	return registerList(scope, collections)
+}
+
 // RegisterSystem binds system functions into the scope.
 func RegisterSystem(scope parser.Scope) error {
 	return registerList(scope, system)
 }
 
+// RegisterTypes binds functions for creating and inspecting values
+// of types registered with the reflection package into the scope.
+func RegisterTypes(scope parser.Scope) error {
+	return registerList(scope, types)
+}
+
 // RegisterIO binds input/output functions into the scope.
 func RegisterIO(scope parser.Scope) error {
 	return registerList(scope, io)
@@ -0,0 +1,182 @@
+package stdlib
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/spy16/parens/parser"
+	"github.com/spy16/parens/reflection"
+	"github.com/spy16/parens/value"
+)
+
+var types = []mapEntry{
+	entry("new", parser.MacroFunc(New),
+		"Creates a new value of a type registered in the type registry",
+		"Usage: (new <type> args...)",
+		"Example: (new Point {:X 1 :Y 2})",
+	),
+	entry("make-slice", parser.NativeFunc(makeSlice),
+		"Creates a slice of the named element type with given length",
+		"Example: (make-slice \"int\" 10)",
+	),
+	entry("make-map", parser.NativeFunc(makeMap),
+		"Creates an empty map of the named key and value types",
+		"Example: (make-map \"string\" \"int\")",
+	),
+	entry("instance?", parser.NativeFunc(isInstance),
+		"Returns true if the value is of the named type or implements the named interface",
+		"Example: (instance? \"string\" \"hello\")",
+	),
+	entry("assert-type", parser.NativeFunc(assertType),
+		"Returns the value if it is of the named type, throws error otherwise",
+		"Example: (assert-type \"Point\" p)",
+	),
+}
+
+// New creates a new value of the type named by the first argument using the
+// default type registry of the reflection package. Remaining arguments are
+// passed to the constructor as they are if one is registered. Otherwise, a
+// single map with keyword or string keys can be passed to initialize the
+// struct fields and a pointer to the new value is returned.
+func New(scope parser.Scope, _ string, exprs []parser.Expr) (interface{}, error) {
+	if len(exprs) == 0 {
+		return nil, fmt.Errorf("at-least 1 argument required, got 0")
+	}
+
+	var typeName string
+	switch nameExpr := exprs[0].(type) {
+	case parser.SymbolExpr:
+		typeName = nameExpr.Symbol
+
+	case parser.StringExpr:
+		name, err := nameExpr.Eval(scope)
+		if err != nil {
+			return nil, err
+		}
+		typeName = name.(string)
+
+	default:
+		return nil, fmt.Errorf("first argument must be a symbol or string, not '%s'", reflect.TypeOf(exprs[0]))
+	}
+
+	args := []interface{}{}
+	for _, expr := range exprs[1:] {
+		arg, err := expr.Eval(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+	}
+
+	types := reflection.DefaultTypes()
+	if len(args) == 1 && !types.HasConstructor(typeName) {
+		fields, err := toFieldMap(args[0])
+		if err != nil {
+			return nil, err
+		}
+		args[0] = fields
+	}
+
+	return types.New(typeName, args...)
+}
+
+func makeSlice(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("exactly 2 arguments required, got %d", len(args))
+	}
+
+	elemType, err := typeNameArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	length, ok := args[1].(int64)
+	if !ok || length < 0 {
+		return nil, fmt.Errorf("length must be a non-negative integer, not '%v'", args[1])
+	}
+
+	return reflection.DefaultTypes().MakeSlice(elemType, int(length))
+}
+
+func makeMap(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("exactly 2 arguments required, got %d", len(args))
+	}
+
+	keyType, err := typeNameArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	valType, err := typeNameArg(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return reflection.DefaultTypes().MakeMap(keyType, valType)
+}
+
+func isInstance(args ...interface{}) (interface{}, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("exactly 2 arguments required, got %d", len(args))
+	}
+
+	typeName, err := typeNameArg(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return reflection.DefaultTypes().IsInstance(typeName, args[1])
+}
+
+func assertType(args ...interface{}) (interface{}, error) {
+	ok, err := isInstance(args...)
+	if err != nil {
+		return nil, err
+	} else if !ok.(bool) {
+		return nil, fmt.Errorf("value of type '%s' is not a '%s'", reflect.TypeOf(args[1]), args[0])
+	}
+
+	return args[1], nil
+}
+
+// typeNameArg returns the type name passed as a string argument.
+func typeNameArg(v interface{}) (string, error) {
+	name, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("type name must be a string, not '%s'", reflect.TypeOf(v))
+	}
+	return name, nil
+}
+
+// toFieldMap converts the map to field names and values using the names of
+// keyword keys so that a map literal can be used to initialize struct
+// fields. Values other than maps are returned as they are.
+func toFieldMap(v interface{}) (interface{}, error) {
+	m, ok := v.(*value.Map)
+	if !ok {
+		return v, nil
+	}
+
+	var err error
+	fields := map[string]interface{}{}
+	m.Range(func(key, val interface{}) bool {
+		switch name := key.(type) {
+		case value.Keyword:
+			fields[name.Name()] = val
+
+		case string:
+			fields[name] = val
+
+		default:
+			err = fmt.Errorf("field name must be a keyword or string, not '%s'", reflect.TypeOf(key))
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,97 @@
+package stdlib_test
+
+import (
+	"testing"
+
+	"github.com/spy16/parens/reflection"
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type vertex struct {
+	X, Y float64
+}
+
+func newVertexFromMap(m *value.Map) vertex {
+	x, _ := m.Get(int64(0))
+	y, _ := m.Get(int64(1))
+	return vertex{X: x.(float64), Y: y.(float64)}
+}
+
+func init() {
+	reflection.RegisterType("Vertex", vertex{})
+	reflection.RegisterConstructor("VertexFromMap", newVertexFromMap)
+}
+
+func TestTypes(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("New", func(t *testing.T) {
+		res, err := execute(t, `(new Vertex {:X 1 :Y 2})`)
+		require.NoError(t, err)
+		assert.Equal(t, &vertex{X: 1, Y: 2}, res)
+	})
+
+	suite.Run("NewWithConstructor", func(t *testing.T) {
+		res, err := execute(t, `(new VertexFromMap {0 1.0 1 2.0})`)
+		require.NoError(t, err)
+		assert.Equal(t, vertex{X: 1, Y: 2}, res)
+	})
+
+	suite.Run("NewZeroValue", func(t *testing.T) {
+		res, err := execute(t, `(new "Vertex")`)
+		require.NoError(t, err)
+		assert.Equal(t, &vertex{}, res)
+	})
+
+	suite.Run("MakeSlice", func(t *testing.T) {
+		res, err := execute(t, `(make-slice "string" 2)`)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"", ""}, res)
+	})
+
+	suite.Run("MakeMap", func(t *testing.T) {
+		res, err := execute(t, `(make-map "string" "int")`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{}, res)
+	})
+
+	suite.Run("InstanceCheck", func(t *testing.T) {
+		res, err := execute(t, `(instance? "*Vertex" (new Vertex))`)
+		require.NoError(t, err)
+		assert.Equal(t, true, res)
+
+		res, err = execute(t, `(instance? "Vertex" "hello")`)
+		require.NoError(t, err)
+		assert.Equal(t, false, res)
+	})
+
+	suite.Run("AssertType", func(t *testing.T) {
+		res, err := execute(t, `(assert-type "string" "hello")`)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", res)
+
+		_, err = execute(t, `(assert-type "int" "hello")`)
+		assert.EqualError(t, err, "value of type 'string' is not a 'int'")
+	})
+
+	suite.Run("Errors", func(t *testing.T) {
+		for _, src := range []string{
+			`(new Vertex {1 2})`,
+			`(new Vertex {:X 1} {:Y 2})`,
+			`(make-slice "NoSuchType" 1)`,
+			`(make-slice "int" -1)`,
+			`(make-slice "int" "1")`,
+			`(make-slice "int")`,
+			`(make-map "string" "NoSuchType")`,
+			`(make-map "[]int" "int")`,
+			`(make-map :string "int")`,
+			`(instance? "NoSuchType" 1)`,
+			`(assert-type "NoSuchType" 1)`,
+		} {
+			_, err := execute(t, src)
+			assert.Error(t, err, src)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package parens_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spy16/parens/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_SyntaxErrors(suite *testing.T) {
+	suite.Parallel()
+
+	cases := map[string][]string{
+		"(a (b c)\n(d":          {"test.lisp:1:1: unclosed list", "test.lisp:2:1: unclosed list"},
+		"(a))\n(b)":             {"test.lisp:1:4: unexpected ')'"},
+		"{:a}\n(x ]":            {"test.lisp:1:1: map literal must contain an even number of forms", "test.lisp:2:1: unclosed list", "test.lisp:2:4: unexpected ']' in list"},
+		"[1 #{2 3]":             {"test.lisp:1:1: unclosed vector", "test.lisp:1:4: unclosed set", "test.lisp:1:9: unexpected ']' in set"},
+		"(a \"\\q\" 1..2 π)":    {"test.lisp:1:4: invalid escape sequence '\\q' in string literal", "test.lisp:1:9: unrecognized token '1..2'"},
+		"(ok)\n  #(#(%))":       {"test.lisp:2:3: nested #() forms are not allowed"},
+		"(a ')":                 {"test.lisp:1:5: unexpected ')'"},
+		"'":                     {"test.lisp:1:2: unexpected end of file"},
+		"{:a 1 \"b\" 2 :a 3}":   {"test.lisp:1:1: duplicate key ':a' in map literal"},
+		"(a #\"[\")":            {"test.lisp:1:4: invalid regex literal #\"[\": error parsing regexp: missing closing ]: `[`"},
+		"; comment\n(π (b) ) )": {"test.lisp:2:10: unexpected ')'"},
+	}
+
+	for src, expected := range cases {
+		src, expected := src, expected
+		suite.Run(src, func(t *testing.T) {
+			expr, err := parser.Parse("test.lisp", src)
+			require.Error(t, err)
+			assert.Nil(t, expr)
+
+			var errList parser.ErrorList
+			require.True(t, errors.As(err, &errList))
+
+			msgs := []string{}
+			for _, synErr := range errList {
+				msgs = append(msgs, synErr.Error())
+			}
+			assert.Equal(t, expected, msgs)
+			assert.Equal(t, strings.Join(expected, "\n"), err.Error())
+		})
+	}
+
+	suite.Run("ErrorsAs", func(t *testing.T) {
+		_, err := parser.Parse("test.lisp", "(a\n  ]")
+
+		var synErr *parser.SyntaxError
+		require.True(t, errors.As(err, &synErr))
+		assert.Equal(t, parser.Position{File: "test.lisp", Line: 1, Column: 1}, synErr.Pos)
+	})
+
+	suite.Run("Reader", func(t *testing.T) {
+		rd := parser.NewReader("test.lisp", strings.NewReader("(a)\n(b\n  ]) )\n\n  (c 1..2)"))
+
+		_, err := rd.Read()
+		require.NoError(t, err)
+
+		_, err = rd.Read()
+		assert.EqualError(t, err, "test.lisp:3:3: unexpected ']' in list")
+
+		_, err = rd.Read()
+		assert.EqualError(t, err, "test.lisp:3:6: unexpected ')'")
+
+		_, err = rd.Read()
+		assert.EqualError(t, err, "test.lisp:5:6: unrecognized token '1..2'")
+	})
+}
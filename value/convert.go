@@ -0,0 +1,54 @@
+package value
+
+import "reflect"
+
+// FromGo converts Go slices to vectors and Go maps to maps recursively.
+// Maps with struct{} values (e.g. map[string]struct{}) are converted to
+// sets. Entries of Go maps are added in the iteration order of the Go map.
+// Only unnamed slice and map types are converted since values of defined
+// types (e.g. net.IP) would lose their methods. []byte is not converted
+// either. Any other value is returned as is.
+func FromGo(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || rv.Type().Name() != "" {
+		return v
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice:
+		if rv.IsNil() || rv.Type().Elem().Kind() == reflect.Uint8 {
+			return v
+		}
+
+		items := make([]interface{}, rv.Len())
+		for i := range items {
+			items[i] = FromGo(rv.Index(i).Interface())
+		}
+		return NewVector(items...)
+
+	case reflect.Map:
+		if rv.IsNil() {
+			return v
+		}
+
+		isSet := rv.Type().Elem() == reflect.TypeOf(struct{}{})
+		set, m := emptySet, emptyMap
+		iter := rv.MapRange()
+		for iter.Next() {
+			// keys of Go maps are always hashable.
+			key := iter.Key().Interface()
+			if isSet {
+				set, _ = set.Conj(key)
+			} else {
+				m, _ = m.Assoc(key, FromGo(iter.Value().Interface()))
+			}
+		}
+
+		if isSet {
+			return set
+		}
+		return m
+	}
+
+	return v
+}
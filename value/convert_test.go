@@ -0,0 +1,48 @@
+package value_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromGo(t *testing.T) {
+	t.Parallel()
+
+	res := value.FromGo([]interface{}{1, []string{"a"}, map[string]int{"k": 2}, map[int]struct{}{3: {}}})
+	vec, ok := res.(*value.Vector)
+	require.True(t, ok)
+	require.Equal(t, 4, vec.Count())
+
+	expectedMap, _ := value.NewMap("k", 2)
+	expectedSet, _ := value.NewSet(3)
+	expected := value.NewVector(1, value.NewVector("a"), expectedMap, expectedSet)
+	assert.True(t, value.Equal(expected, vec), "%v", vec)
+
+	assert.Equal(t, "str", value.FromGo("str"))
+	assert.Equal(t, []int(nil), value.FromGo([]int(nil)))
+}
+
+func TestFromGo_KeepsTypes(t *testing.T) {
+	t.Parallel()
+
+	type names []string
+	type ages map[string]int
+
+	for _, v := range []interface{}{
+		names{"a"},
+		ages{"a": 1},
+		[]byte("abc"),
+		net.IP{127, 0, 0, 1},
+		[2]int{1, 2},
+	} {
+		assert.Equal(t, v, value.FromGo(v))
+	}
+
+	vec := value.FromGo([]names{{"a"}}).(*value.Vector)
+	item, _ := vec.Nth(0)
+	assert.Equal(t, names{"a"}, item)
+}
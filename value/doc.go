@@ -0,0 +1,5 @@
+// Package value provides the runtime types of values created by evaluating
+// parens forms (e.g. vectors, maps, sets, keywords). Collections are
+// persistent: they are never modified in place and updates return a new
+// collection which shares most of its structure with the original.
+package value
@@ -0,0 +1,75 @@
+package value
+
+import "reflect"
+
+// Equal returns true if the values are equal. Vectors, maps and sets are
+// equal if they contain equal items (ignoring the order of the entries in
+// case of maps and sets). Lists and other sequences are equal if they
+// contain equal items in the same order. Other values are compared using
+// reflect.DeepEqual.
+func Equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case *Vector:
+		y, ok := b.(*Vector)
+		if !ok || x.Count() != y.Count() {
+			return false
+		}
+
+		equal := true
+		x.Range(func(i int, item interface{}) bool {
+			other, _ := y.Nth(i)
+			equal = Equal(item, other)
+			return equal
+		})
+		return equal
+
+	case *Map:
+		y, ok := b.(*Map)
+		if !ok || x.Count() != y.Count() {
+			return false
+		}
+
+		equal := true
+		x.Range(func(key, val interface{}) bool {
+			other, found := y.Get(key)
+			equal = found && Equal(val, other)
+			return equal
+		})
+		return equal
+
+	case *Set:
+		y, ok := b.(*Set)
+		if !ok || x.Count() != y.Count() {
+			return false
+		}
+
+		equal := true
+		x.Range(func(item interface{}) bool {
+			equal = y.Contains(item)
+			return equal
+		})
+		return equal
+
+	case Seq:
+		y, ok := b.(Seq)
+		return ok && seqEqual(x, y)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// seqEqual realizes the sequences and compares the items in order.
+func seqEqual(x, y Seq) bool {
+	for {
+		xFirst, xRest, xOK, xErr := x.Uncons()
+		yFirst, yRest, yOK, yErr := y.Uncons()
+		if xErr != nil || yErr != nil || xOK != yOK {
+			return false
+		} else if !xOK {
+			return true
+		} else if !Equal(xFirst, yFirst) {
+			return false
+		}
+		x, y = xRest, yRest
+	}
+}
@@ -0,0 +1,42 @@
+package value_test
+
+import (
+	"testing"
+
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqual(suite *testing.T) {
+	suite.Parallel()
+
+	m1, err := value.NewMap("a", 1, "b", value.NewVector(1, 2))
+	require.NoError(suite, err)
+	m2, err := value.NewMap("b", value.NewVector(1, 2), "a", 1)
+	require.NoError(suite, err)
+	s1, err := value.NewSet(1, 2, 3)
+	require.NoError(suite, err)
+	s2, err := value.NewSet(3, 2, 1)
+	require.NoError(suite, err)
+
+	cases := []struct {
+		a, b  interface{}
+		equal bool
+	}{
+		{a: value.NewVector(1, "a"), b: value.NewVector(1, "a"), equal: true},
+		{a: value.NewVector(1, "a"), b: value.NewVector("a", 1), equal: false},
+		{a: value.NewVector(1), b: value.NewVector(1, 2), equal: false},
+		{a: value.NewVector(1), b: []interface{}{1}, equal: false},
+		{a: m1, b: m2, equal: true},
+		{a: m1, b: m1.Dissoc("a"), equal: false},
+		{a: s1, b: s2, equal: true},
+		{a: s1, b: s1.Disj(1), equal: false},
+		{a: "a", b: "a", equal: true},
+		{a: []int{1}, b: []int{1}, equal: true},
+	}
+
+	for _, cs := range cases {
+		assert.Equal(suite, cs.equal, value.Equal(cs.a, cs.b), "%v = %v", cs.a, cs.b)
+	}
+}
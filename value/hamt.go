@@ -0,0 +1,244 @@
+package value
+
+import (
+	"hash/maphash"
+	"math/bits"
+)
+
+// seed is used for hashing the keys of all the maps and sets.
+var seed = maphash.MakeSeed()
+
+// hashKey returns the hash of the key and true if the key is hashable.
+// Lists, vectors, maps and sets are hashed by their contents so that equal
+// collections can be used interchangeably as keys. Other values must be
+// comparable with == (e.g. not Go slices or structs containing slices).
+func hashKey(key interface{}) (hash uint64, ok bool) {
+	switch k := key.(type) {
+	case *List:
+		return hashItems(k.Range)
+
+	case *Vector:
+		return hashItems(k.Range)
+
+	case *Map:
+		// entries are combined with + so that the order does not matter.
+		ok = true
+		k.Range(func(key, val interface{}) bool {
+			keyHash, keyOK := hashKey(key)
+			valHash, valOK := hashKey(val)
+			hash += keyHash ^ (valHash * 31)
+			ok = keyOK && valOK
+			return ok
+		})
+		return hash, ok
+
+	case *Set:
+		ok = true
+		k.Range(func(item interface{}) bool {
+			var itemHash uint64
+			itemHash, ok = hashKey(item)
+			hash += itemHash
+			return ok
+		})
+		return hash, ok
+	}
+
+	defer func() {
+		if recover() != nil {
+			hash, ok = 0, false
+		}
+	}()
+
+	return maphash.Comparable(seed, key), true
+}
+
+// hashItems combines the hashes of the items in order.
+func hashItems(rangeFn func(fn func(i int, item interface{}) bool)) (uint64, bool) {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	ok := true
+	rangeFn(func(_ int, item interface{}) bool {
+		var itemHash uint64
+		itemHash, ok = hashKey(item)
+		maphash.WriteComparable(&h, itemHash)
+		return ok
+	})
+	return h.Sum64(), ok
+}
+
+// keyEqual compares the keys. Collections are compared by their contents
+// and everything else using ==.
+func keyEqual(a, b interface{}) bool {
+	switch a.(type) {
+	case *List, *Vector, *Map, *Set:
+		return Equal(a, b)
+	}
+	return a == b
+}
+
+// hnode is a node of the hash array mapped trie (HAMT) that maps keys to
+// their positions in a Map. Each level uses 5 bits of the hash to select
+// a slot and the bitmap tracks which of the 32 possible slots are in use.
+// Slots contain either a *hleaf or a child *hnode. Keys whose hashes are
+// entirely equal end up in the collisions of a node at the last level.
+type hnode struct {
+	bitmap     uint32
+	slots      []interface{}
+	collisions []*hleaf
+}
+
+type hleaf struct {
+	hash uint64
+	key  interface{}
+	pos  int
+}
+
+// maxShift is the shift at which all the bits of the hash are used up.
+const maxShift = 64
+
+func (node *hnode) find(hash uint64, key interface{}) *hleaf {
+	for shift := uint(0); ; shift += nodeBits {
+		if shift >= maxShift {
+			for _, leaf := range node.collisions {
+				if keyEqual(leaf.key, key) {
+					return leaf
+				}
+			}
+			return nil
+		}
+
+		bit := uint32(1) << ((hash >> shift) & nodeMask)
+		if node.bitmap&bit == 0 {
+			return nil
+		}
+
+		switch slot := node.slots[slotIndex(node.bitmap, bit)].(type) {
+		case *hleaf:
+			if slot.hash == hash && keyEqual(slot.key, key) {
+				return slot
+			}
+			return nil
+
+		case *hnode:
+			node = slot
+		}
+	}
+}
+
+// assoc returns a new node with the leaf added or replacing the leaf with
+// equal key. Returns true if the leaf was added.
+func (node *hnode) assoc(shift uint, leaf *hleaf) (*hnode, bool) {
+	if shift >= maxShift {
+		res := &hnode{collisions: append([]*hleaf(nil), node.collisions...)}
+		for i, existing := range res.collisions {
+			if keyEqual(existing.key, leaf.key) {
+				res.collisions[i] = leaf
+				return res, false
+			}
+		}
+		res.collisions = append(res.collisions, leaf)
+		return res, true
+	}
+
+	bit := uint32(1) << ((leaf.hash >> shift) & nodeMask)
+	idx := slotIndex(node.bitmap, bit)
+	if node.bitmap&bit == 0 {
+		res := &hnode{bitmap: node.bitmap | bit, slots: make([]interface{}, 0, len(node.slots)+1)}
+		res.slots = append(res.slots, node.slots[:idx]...)
+		res.slots = append(res.slots, leaf)
+		res.slots = append(res.slots, node.slots[idx:]...)
+		return res, true
+	}
+
+	var child interface{}
+	added := false
+	switch slot := node.slots[idx].(type) {
+	case *hleaf:
+		if slot.hash == leaf.hash && keyEqual(slot.key, leaf.key) {
+			child = leaf
+		} else {
+			// two different keys in the same slot. push both down.
+			sub, _ := (&hnode{}).assoc(shift+nodeBits, slot)
+			sub, _ = sub.assoc(shift+nodeBits, leaf)
+			child, added = sub, true
+		}
+
+	case *hnode:
+		child, added = slot.assoc(shift+nodeBits, leaf)
+	}
+
+	res := &hnode{bitmap: node.bitmap, slots: append([]interface{}(nil), node.slots...)}
+	res.slots[idx] = child
+	return res, added
+}
+
+// dissoc returns a new node without the key. Returns the node itself and
+// false if the key does not exist.
+func (node *hnode) dissoc(shift uint, hash uint64, key interface{}) (*hnode, bool) {
+	if shift >= maxShift {
+		for i, leaf := range node.collisions {
+			if keyEqual(leaf.key, key) {
+				res := &hnode{collisions: make([]*hleaf, 0, len(node.collisions)-1)}
+				res.collisions = append(res.collisions, node.collisions[:i]...)
+				res.collisions = append(res.collisions, node.collisions[i+1:]...)
+				return res, true
+			}
+		}
+		return node, false
+	}
+
+	bit := uint32(1) << ((hash >> shift) & nodeMask)
+	if node.bitmap&bit == 0 {
+		return node, false
+	}
+
+	idx := slotIndex(node.bitmap, bit)
+	var child interface{}
+	switch slot := node.slots[idx].(type) {
+	case *hleaf:
+		if slot.hash != hash || !keyEqual(slot.key, key) {
+			return node, false
+		}
+
+	case *hnode:
+		sub, removed := slot.dissoc(shift+nodeBits, hash, key)
+		if !removed {
+			return node, false
+		}
+		child = sub.collapse()
+	}
+
+	res := &hnode{bitmap: node.bitmap, slots: append([]interface{}(nil), node.slots...)}
+	if child == nil {
+		res.bitmap &^= bit
+		res.slots = append(res.slots[:idx], res.slots[idx+1:]...)
+	} else {
+		res.slots[idx] = child
+	}
+	return res, true
+}
+
+// collapse returns the slot content that can replace the node in its
+// parent: nil if the node is empty, the leaf if the node has just one
+// leaf and the node itself otherwise.
+func (node *hnode) collapse() interface{} {
+	switch {
+	case len(node.slots) == 0 && len(node.collisions) == 0:
+		return nil
+
+	case len(node.collisions) == 1:
+		return node.collisions[0]
+
+	case len(node.slots) == 1:
+		if leaf, ok := node.slots[0].(*hleaf); ok {
+			return leaf
+		}
+	}
+	return node
+}
+
+// slotIndex returns the index of the slot for the bit in the compact slots
+// array, i.e., the number of slots in use before the bit.
+func slotIndex(bitmap, bit uint32) int {
+	return bits.OnesCount32(bitmap & (bit - 1))
+}
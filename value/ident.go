@@ -0,0 +1,118 @@
+package value
+
+import (
+	"strings"
+	"unique"
+)
+
+// Keyword is a symbolic identifier that evaluates to itself (e.g. :name,
+// :user/id). Keywords are interned, i.e., keywords with the same namespace
+// and name are the same value and can be compared using ==. Interned
+// keywords that are no longer referenced are garbage collected.
+type Keyword struct {
+	id unique.Handle[ident]
+}
+
+// NewKeyword returns the keyword with the given namespace and name. The
+// namespace can be empty.
+func NewKeyword(ns, name string) Keyword {
+	return Keyword{id: intern(ns, name)}
+}
+
+// ParseKeyword returns the keyword represented by the string with or
+// without the leading ':' (e.g. ":user/id" or "user/id").
+func ParseKeyword(str string) Keyword {
+	ns, name := splitIdent(strings.TrimPrefix(str, ":"))
+	return NewKeyword(ns, name)
+}
+
+// Namespace returns the namespace of the keyword or empty string if the
+// keyword has no namespace.
+func (kw Keyword) Namespace() string {
+	ns, _, _ := parts(kw.id)
+	return ns
+}
+
+// Name returns the name of the keyword without the namespace.
+func (kw Keyword) Name() string {
+	_, name, _ := parts(kw.id)
+	return name
+}
+
+func (kw Keyword) String() string {
+	_, _, str := parts(kw.id)
+	return ":" + str
+}
+
+// Symbol is an identifier which is used to refer to other values (e.g.
+// functions). Symbols are interned the same way as keywords.
+type Symbol struct {
+	id unique.Handle[ident]
+}
+
+// NewSymbol returns the symbol with the given namespace and name. The
+// namespace can be empty.
+func NewSymbol(ns, name string) Symbol {
+	return Symbol{id: intern(ns, name)}
+}
+
+// ParseSymbol returns the symbol represented by the string (e.g. "user/id").
+func ParseSymbol(str string) Symbol {
+	ns, name := splitIdent(str)
+	return NewSymbol(ns, name)
+}
+
+// Namespace returns the namespace of the symbol or empty string if the
+// symbol has no namespace.
+func (sym Symbol) Namespace() string {
+	ns, _, _ := parts(sym.id)
+	return ns
+}
+
+// Name returns the name of the symbol without the namespace.
+func (sym Symbol) Name() string {
+	_, name, _ := parts(sym.id)
+	return name
+}
+
+func (sym Symbol) String() string {
+	_, _, str := parts(sym.id)
+	return str
+}
+
+// ident is the interned namespace and name of a keyword or symbol.
+type ident struct {
+	ns, name string
+	str      string
+}
+
+// parts returns the namespace, name and the string form of the identifier.
+// Zero value of Keyword and Symbol have no identifier.
+func parts(id unique.Handle[ident]) (ns, name, str string) {
+	if id == (unique.Handle[ident]{}) {
+		return "", "", ""
+	}
+	v := id.Value()
+	return v.ns, v.name, v.str
+}
+
+// intern returns the handle of the identifier. Unlike a plain map, the
+// handles are weak and identifiers no longer referenced are reclaimed.
+func intern(ns, name string) unique.Handle[ident] {
+	str := name
+	if ns != "" {
+		str = ns + "/" + name
+	}
+	return unique.Make(ident{ns: ns, name: name, str: str})
+}
+
+// splitIdent splits the string into namespace and name at the first '/'.
+// The string is the name if it has no '/' or if either part would be empty
+// (e.g. "/").
+func splitIdent(str string) (ns, name string) {
+	idx := strings.IndexByte(str, '/')
+	if idx <= 0 || idx == len(str)-1 {
+		return "", str
+	}
+	return str[:idx], str[idx+1:]
+}
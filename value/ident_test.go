@@ -0,0 +1,72 @@
+package value_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyword(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("Interned", func(t *testing.T) {
+		assert.True(t, value.ParseKeyword(":user/id") == value.NewKeyword("user", "id"))
+		assert.True(t, value.ParseKeyword("name") == value.ParseKeyword(":name"))
+		assert.False(t, value.ParseKeyword(":id") == value.ParseKeyword(":user/id"))
+	})
+
+	suite.Run("Parts", func(t *testing.T) {
+		cases := []struct {
+			str, ns, name, repr string
+		}{
+			{str: ":name", ns: "", name: "name", repr: ":name"},
+			{str: ":user/id", ns: "user", name: "id", repr: ":user/id"},
+			{str: ":a/b/c", ns: "a", name: "b/c", repr: ":a/b/c"},
+			{str: ":/", ns: "", name: "/", repr: ":/"},
+			{str: ":a/", ns: "", name: "a/", repr: ":a/"},
+		}
+
+		for _, cs := range cases {
+			kw := value.ParseKeyword(cs.str)
+			assert.Equal(t, cs.ns, kw.Namespace(), cs.str)
+			assert.Equal(t, cs.name, kw.Name(), cs.str)
+			assert.Equal(t, cs.repr, kw.String(), cs.str)
+		}
+	})
+
+	suite.Run("InternedAfterGC", func(t *testing.T) {
+		kw := value.ParseKeyword(":gc/kept")
+		runtime.GC()
+		assert.True(t, kw == value.NewKeyword("gc", "kept"))
+		assert.Equal(t, ":gc/kept", kw.String())
+	})
+
+	suite.Run("ZeroValue", func(t *testing.T) {
+		var kw value.Keyword
+		assert.Equal(t, "", kw.Name())
+		assert.Equal(t, ":", kw.String())
+	})
+}
+
+func TestSymbol(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("Interned", func(t *testing.T) {
+		assert.True(t, value.ParseSymbol("user/id") == value.NewSymbol("user", "id"))
+		assert.False(t, value.ParseSymbol("name") == value.ParseSymbol("other"))
+	})
+
+	suite.Run("NotKeyword", func(t *testing.T) {
+		var sym, kw interface{} = value.ParseSymbol("name"), value.ParseKeyword("name")
+		assert.False(t, sym == kw)
+	})
+
+	suite.Run("Parts", func(t *testing.T) {
+		sym := value.ParseSymbol("str/join")
+		assert.Equal(t, "str", sym.Namespace())
+		assert.Equal(t, "join", sym.Name())
+		assert.Equal(t, "str/join", sym.String())
+	})
+}
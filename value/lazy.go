@@ -0,0 +1,187 @@
+package value
+
+// MapSeq returns a lazy sequence of the results of calling fn with the
+// items of the sequence.
+func MapSeq(fn func(item interface{}) (interface{}, error), seq Seq) Seq {
+	return NewLazySeq(func() (Seq, error) {
+		item, rest, ok, err := seq.Uncons()
+		if err != nil || !ok {
+			return nil, err
+		}
+
+		res, err := fn(item)
+		if err != nil {
+			return nil, err
+		}
+		return &consSeq{first: res, rest: MapSeq(fn, rest)}, nil
+	})
+}
+
+// FilterSeq returns a lazy sequence of the items of the sequence for which
+// pred returns true.
+func FilterSeq(pred func(item interface{}) (bool, error), seq Seq) Seq {
+	return NewLazySeq(func() (Seq, error) {
+		for {
+			item, rest, ok, err := seq.Uncons()
+			if err != nil || !ok {
+				return nil, err
+			}
+
+			keep, err := pred(item)
+			if err != nil {
+				return nil, err
+			} else if keep {
+				return &consSeq{first: item, rest: FilterSeq(pred, rest)}, nil
+			}
+			seq = rest
+		}
+	})
+}
+
+// TakeSeq returns a lazy sequence of the first n items of the sequence.
+func TakeSeq(n int, seq Seq) Seq {
+	return NewLazySeq(func() (Seq, error) {
+		if n <= 0 {
+			return nil, nil
+		}
+
+		item, rest, ok, err := seq.Uncons()
+		if err != nil || !ok {
+			return nil, err
+		}
+		return &consSeq{first: item, rest: TakeSeq(n-1, rest)}, nil
+	})
+}
+
+// TakeWhileSeq returns a lazy sequence of the items of the sequence until
+// pred returns false for an item.
+func TakeWhileSeq(pred func(item interface{}) (bool, error), seq Seq) Seq {
+	return NewLazySeq(func() (Seq, error) {
+		item, rest, ok, err := seq.Uncons()
+		if err != nil || !ok {
+			return nil, err
+		}
+
+		take, err := pred(item)
+		if err != nil || !take {
+			return nil, err
+		}
+		return &consSeq{first: item, rest: TakeWhileSeq(pred, rest)}, nil
+	})
+}
+
+// DropSeq returns a lazy sequence of the items of the sequence after the
+// first n items.
+func DropSeq(n int, seq Seq) Seq {
+	return NewLazySeq(func() (Seq, error) {
+		for ; n > 0; n-- {
+			_, rest, ok, err := seq.Uncons()
+			if err != nil || !ok {
+				return nil, err
+			}
+			seq = rest
+		}
+		return seq, nil
+	})
+}
+
+// IterateSeq returns an infinite lazy sequence of x, fn(x), fn(fn(x)) etc.
+func IterateSeq(fn func(item interface{}) (interface{}, error), x interface{}) Seq {
+	return &consSeq{
+		first: x,
+		rest: NewLazySeq(func() (Seq, error) {
+			next, err := fn(x)
+			if err != nil {
+				return nil, err
+			}
+			return IterateSeq(fn, next), nil
+		}),
+	}
+}
+
+// ConcatSeq returns a lazy sequence of the items of all the sequences one
+// after another.
+func ConcatSeq(seqs ...Seq) Seq {
+	return NewLazySeq(func() (Seq, error) {
+		for len(seqs) > 0 {
+			item, rest, ok, err := seqs[0].Uncons()
+			if err != nil {
+				return nil, err
+			} else if ok {
+				more := append([]Seq{rest}, seqs[1:]...)
+				return &consSeq{first: item, rest: ConcatSeq(more...)}, nil
+			}
+			seqs = seqs[1:]
+		}
+		return nil, nil
+	})
+}
+
+// ZipSeq returns a lazy sequence of vectors of the first items of all the
+// sequences, then the second items and so on until any of the sequences
+// ends.
+func ZipSeq(seqs ...Seq) Seq {
+	return NewLazySeq(func() (Seq, error) {
+		if len(seqs) == 0 {
+			return nil, nil
+		}
+
+		items := make([]interface{}, len(seqs))
+		rests := make([]Seq, len(seqs))
+		for i, seq := range seqs {
+			item, rest, ok, err := seq.Uncons()
+			if err != nil || !ok {
+				return nil, err
+			}
+			items[i], rests[i] = item, rest
+		}
+		return &consSeq{first: NewVector(items...), rest: ZipSeq(rests...)}, nil
+	})
+}
+
+// DistinctSeq returns a lazy sequence of the items of the sequence without
+// duplicates. Items must be usable as set items.
+func DistinctSeq(seq Seq) Seq {
+	return distinctSeq(emptySet, seq)
+}
+
+func distinctSeq(seen *Set, seq Seq) Seq {
+	return NewLazySeq(func() (Seq, error) {
+		for {
+			item, rest, ok, err := seq.Uncons()
+			if err != nil || !ok {
+				return nil, err
+			}
+
+			if !seen.Contains(item) {
+				if seen, err = seen.Conj(item); err != nil {
+					return nil, err
+				}
+				return &consSeq{first: item, rest: distinctSeq(seen, rest)}, nil
+			}
+			seq = rest
+		}
+	})
+}
+
+// PartitionSeq returns a lazy sequence of lists of n items each, starting
+// step items apart. Items at the end which do not fill a list of n items
+// are dropped.
+func PartitionSeq(n, step int, seq Seq) Seq {
+	return NewLazySeq(func() (Seq, error) {
+		if n <= 0 || step <= 0 {
+			return nil, nil
+		}
+
+		items := make([]interface{}, 0, n)
+		for rest := seq; len(items) < n; {
+			item, next, ok, err := rest.Uncons()
+			if err != nil || !ok {
+				return nil, err
+			}
+			items, rest = append(items, item), next
+		}
+
+		return &consSeq{first: NewList(items...), rest: PartitionSeq(n, step, DropSeq(step, seq))}, nil
+	})
+}
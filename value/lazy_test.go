@@ -0,0 +1,108 @@
+package value_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inc(v interface{}) (interface{}, error) { return v.(int) + 1, nil }
+
+func even(v interface{}) (bool, error) { return v.(int)%2 == 0, nil }
+
+func TestLazySeqFunctions(suite *testing.T) {
+	suite.Parallel()
+
+	naturals := value.IterateSeq(inc, 0)
+
+	cases := map[string]struct {
+		seq   value.Seq
+		items []interface{}
+	}{
+		"Iterate":    {seq: value.TakeSeq(3, naturals), items: []interface{}{0, 1, 2}},
+		"Map":        {seq: value.TakeSeq(3, value.MapSeq(inc, naturals)), items: []interface{}{1, 2, 3}},
+		"Filter":     {seq: value.TakeSeq(3, value.FilterSeq(even, naturals)), items: []interface{}{0, 2, 4}},
+		"Drop":       {seq: value.TakeSeq(2, value.DropSeq(5, naturals)), items: []interface{}{5, 6}},
+		"DropAll":    {seq: value.DropSeq(5, value.NewList(1, 2)), items: []interface{}{}},
+		"TakeMore":   {seq: value.TakeSeq(5, value.NewList(1, 2)), items: []interface{}{1, 2}},
+		"TakeNone":   {seq: value.TakeSeq(0, naturals), items: []interface{}{}},
+		"TakeWhile":  {seq: value.TakeWhileSeq(func(v interface{}) (bool, error) { return v.(int) < 3, nil }, naturals), items: []interface{}{0, 1, 2}},
+		"FilterNone": {seq: value.FilterSeq(even, value.NewList(1, 3, 5)), items: []interface{}{}},
+		"DeepDrop":   {seq: value.TakeSeq(1, value.DropSeq(200000, naturals)), items: []interface{}{200000}},
+		"Concat":     {seq: value.ConcatSeq(value.NewList(1, 2), value.NewList(), value.NewList(3)), items: []interface{}{1, 2, 3}},
+		"ConcatNone": {seq: value.ConcatSeq(), items: []interface{}{}},
+		"ConcatLazy": {seq: value.TakeSeq(3, value.ConcatSeq(value.NewList(-1), naturals)), items: []interface{}{-1, 0, 1}},
+		"Zip":        {seq: value.ZipSeq(naturals, value.NewList("a", "b")), items: []interface{}{value.NewVector(0, "a"), value.NewVector(1, "b")}},
+		"Distinct":   {seq: value.DistinctSeq(value.NewList(1, 2, 1, 3, 2)), items: []interface{}{1, 2, 3}},
+		"Partition":  {seq: value.PartitionSeq(2, 2, value.NewList(1, 2, 3, 4, 5)), items: []interface{}{value.NewList(1, 2), value.NewList(3, 4)}},
+		"PartitionStep": {
+			seq:   value.PartitionSeq(2, 1, value.NewList(1, 2, 3)),
+			items: []interface{}{value.NewList(1, 2), value.NewList(2, 3)},
+		},
+		"PartitionLazy": {
+			seq:   value.TakeSeq(2, value.PartitionSeq(3, 3, naturals)),
+			items: []interface{}{value.NewList(0, 1, 2), value.NewList(3, 4, 5)},
+		},
+	}
+
+	for name, cs := range cases {
+		cs := cs
+		suite.Run(name, func(t *testing.T) {
+			items, err := value.SeqItems(cs.seq)
+			require.NoError(t, err)
+			assert.Equal(t, cs.items, items)
+		})
+	}
+}
+
+func TestLazySeqFunctions_Laziness(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	mapped := value.MapSeq(func(v interface{}) (interface{}, error) {
+		calls++
+		return v, nil
+	}, value.NewList(1, 2, 3))
+	assert.Equal(t, 0, calls)
+
+	_, err := value.SeqItems(value.TakeSeq(2, mapped))
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	_, err = value.SeqItems(mapped)
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestLazySeqFunctions_Errors(t *testing.T) {
+	t.Parallel()
+
+	failing := func(v interface{}) (interface{}, error) {
+		if v.(int) == 2 {
+			return nil, errors.New("failed at 2")
+		}
+		return v, nil
+	}
+
+	_, err := value.SeqItems(value.MapSeq(failing, value.NewList(1, 2, 3)))
+	assert.EqualError(t, err, "failed at 2")
+
+	items, err := value.SeqItems(value.TakeSeq(1, value.MapSeq(failing, value.NewList(1, 2, 3))))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{1}, items)
+
+	failingInc := func(v interface{}) (interface{}, error) {
+		if _, err := failing(v); err != nil {
+			return nil, err
+		}
+		return inc(v)
+	}
+	_, err = value.SeqItems(value.TakeSeq(5, value.IterateSeq(failingInc, 0)))
+	assert.EqualError(t, err, "failed at 2")
+
+	_, err = value.SeqItems(value.DistinctSeq(value.NewList(1, []int{1})))
+	assert.EqualError(t, err, "value of type '[]int' cannot be a set item")
+}
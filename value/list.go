@@ -0,0 +1,101 @@
+package value
+
+import (
+	"fmt"
+	"strings"
+)
+
+// List is a persistent (immutable) singly linked list of cons cells. Adding
+// an item to the front shares the entire original list as the rest of the
+// new list. A nil or zero List is an empty list.
+type List struct {
+	first interface{}
+	rest  *List
+	count int
+}
+
+var emptyList = &List{}
+
+// NewList creates a list with the given items in the same order.
+func NewList(items ...interface{}) *List {
+	list := emptyList
+	for i := len(items) - 1; i >= 0; i-- {
+		list = list.Conj(items[i])
+	}
+	return list
+}
+
+// Cons returns a new list with the item in front of the rest.
+func Cons(item interface{}, rest *List) *List {
+	return rest.Conj(item)
+}
+
+// Count returns the number of items in the list.
+func (list *List) Count() int {
+	if list == nil {
+		return 0
+	}
+	return list.count
+}
+
+// First returns the first item of the list or nil if the list is empty.
+func (list *List) First() interface{} {
+	if list == nil {
+		return nil
+	}
+	return list.first
+}
+
+// Rest returns the list without the first item. Rest of an empty list is
+// an empty list.
+func (list *List) Rest() *List {
+	if list.Count() <= 1 {
+		return emptyList
+	}
+	return list.rest
+}
+
+// Uncons returns the first item and the rest of the list. Implements Seq.
+func (list *List) Uncons() (interface{}, Seq, bool, error) {
+	if list.Count() == 0 {
+		return nil, nil, false, nil
+	}
+	return list.first, list.Rest(), true, nil
+}
+
+// Conj returns a new list with the item added to the front.
+func (list *List) Conj(item interface{}) *List {
+	if list == nil {
+		list = emptyList
+	}
+	return &List{first: item, rest: list, count: list.count + 1}
+}
+
+// Range calls fn for every item in order until fn returns false.
+func (list *List) Range(fn func(i int, item interface{}) bool) {
+	for i := 0; list.Count() > 0; i++ {
+		if !fn(i, list.first) {
+			return
+		}
+		list = list.rest
+	}
+}
+
+// Slice returns the items of the list as a new slice.
+func (list *List) Slice() []interface{} {
+	items := make([]interface{}, 0, list.Count())
+	list.Range(func(_ int, item interface{}) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+func (list *List) String() string {
+	strs := make([]string, 0, list.Count())
+	list.Range(func(_ int, item interface{}) bool {
+		strs = append(strs, fmt.Sprint(item))
+		return true
+	})
+	return fmt.Sprintf("(%s)", strings.Join(strs, " "))
+}
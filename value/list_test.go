@@ -0,0 +1,65 @@
+package value_test
+
+import (
+	"testing"
+
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewList(t *testing.T) {
+	t.Parallel()
+
+	list := value.NewList(1, 2, 3)
+	assert.Equal(t, 3, list.Count())
+	assert.Equal(t, 1, list.First())
+	assert.Equal(t, []interface{}{2, 3}, list.Rest().Slice())
+	assert.Equal(t, "(1 2 3)", list.String())
+
+	empty := value.NewList()
+	assert.Equal(t, 0, empty.Count())
+	assert.Nil(t, empty.First())
+	assert.Equal(t, 0, empty.Rest().Count())
+	assert.Equal(t, "()", empty.String())
+}
+
+func TestList_Cons(t *testing.T) {
+	t.Parallel()
+
+	rest := value.NewList(2, 3)
+	list := value.Cons(1, rest)
+	assert.Equal(t, []interface{}{1, 2, 3}, list.Slice())
+	assert.True(t, list.Rest() == rest)
+	assert.Equal(t, []interface{}{2, 3}, rest.Slice())
+
+	list = rest.Conj(1).Conj(0)
+	assert.Equal(t, []interface{}{0, 1, 2, 3}, list.Slice())
+
+	var nilList *value.List
+	assert.Equal(t, []interface{}{1}, value.Cons(1, nilList).Slice())
+}
+
+func TestList_Range(t *testing.T) {
+	t.Parallel()
+
+	var items []interface{}
+	value.NewList(seq(10)...).Range(func(i int, item interface{}) bool {
+		items = append(items, item)
+		return i < 4
+	})
+	assert.Equal(t, seq(5), items)
+}
+
+func TestList_Equal(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, value.Equal(value.NewList(1, value.NewVector(2)), value.NewList(1, value.NewVector(2))))
+	assert.False(t, value.Equal(value.NewList(1, 2), value.NewList(2, 1)))
+	assert.False(t, value.Equal(value.NewList(1, 2), value.NewVector(1, 2)))
+
+	m, err := value.NewMap(value.NewList(1, 2), "found")
+	assert.NoError(t, err)
+	val, found := m.Get(value.NewList(1, 2))
+	assert.True(t, found)
+	assert.Equal(t, "found", val)
+}
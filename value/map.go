@@ -0,0 +1,169 @@
+package value
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Map is a persistent (immutable) hash map that remembers the order in which
+// the keys were added. Keys can be lists, vectors, maps, sets or any value
+// comparable with ==. Collections are compared by their contents. Updates
+// return a new map which shares most of its structure with the original. A
+// nil or zero Map is an empty map.
+//
+// Keys are mapped to their positions in an order vector of entries by a hash
+// array mapped trie. Removed entries leave a hole in the vector which is
+// reclaimed once the holes outnumber the entries.
+type Map struct {
+	root  *hnode
+	count int
+	order *Vector
+}
+
+type mapEntry struct {
+	key, val interface{}
+}
+
+// hole marks a removed entry in the order vector.
+type hole struct{}
+
+var emptyMap = &Map{root: &hnode{}, order: emptyVector}
+
+// NewMap creates a map from the alternating keys and values. Later values
+// replace the earlier ones for equal keys. Returns error if the number of
+// arguments is odd or a key is not hashable.
+func NewMap(kvs ...interface{}) (*Map, error) {
+	if len(kvs)%2 != 0 {
+		return nil, fmt.Errorf("map requires an even number of forms, got %d", len(kvs))
+	}
+
+	m := emptyMap
+	for i := 0; i < len(kvs); i += 2 {
+		var err error
+		if m, err = m.Assoc(kvs[i], kvs[i+1]); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Count returns the number of entries in the map.
+func (m *Map) Count() int {
+	if m == nil {
+		return 0
+	}
+	return m.count
+}
+
+// Get returns the value for the key and true if the key exists.
+func (m *Map) Get(key interface{}) (interface{}, bool) {
+	leaf := m.find(key)
+	if leaf == nil {
+		return nil, false
+	}
+
+	entry, _ := m.order.Nth(leaf.pos)
+	return entry.(mapEntry).val, true
+}
+
+// Assoc returns a new map with the key set to val. Existing keys retain
+// their position.
+func (m *Map) Assoc(key, val interface{}) (*Map, error) {
+	hash, ok := hashKey(key)
+	if !ok {
+		return nil, fmt.Errorf("value of type '%s' cannot be a map key", reflect.TypeOf(key))
+	}
+
+	if m == nil || m.root == nil {
+		m = emptyMap
+	}
+
+	if leaf := m.root.find(hash, key); leaf != nil {
+		order, _ := m.order.Assoc(leaf.pos, mapEntry{key: key, val: val})
+		return &Map{root: m.root, count: m.count, order: order}, nil
+	}
+
+	root, _ := m.root.assoc(0, &hleaf{hash: hash, key: key, pos: m.order.Count()})
+	return &Map{
+		root:  root,
+		count: m.count + 1,
+		order: m.order.Conj(mapEntry{key: key, val: val}),
+	}, nil
+}
+
+// Dissoc returns a new map without the key.
+func (m *Map) Dissoc(key interface{}) *Map {
+	leaf := m.find(key)
+	if leaf == nil {
+		return m
+	}
+
+	root, _ := m.root.dissoc(0, leaf.hash, key)
+	res := &Map{root: root, count: m.count - 1}
+	if leaf.pos == m.order.Count()-1 {
+		res.order, _ = m.order.Pop()
+	} else {
+		res.order, _ = m.order.Assoc(leaf.pos, hole{})
+	}
+
+	if holes := res.order.Count() - res.count; holes > res.count {
+		return res.compact()
+	}
+	return res
+}
+
+// Keys returns the keys in the order they were added.
+func (m *Map) Keys() []interface{} {
+	keys := make([]interface{}, 0, m.Count())
+	m.Range(func(key, _ interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Range calls fn for every entry in the order the keys were added until fn
+// returns false.
+func (m *Map) Range(fn func(key, val interface{}) bool) {
+	if m == nil {
+		return
+	}
+
+	m.order.Range(func(_ int, item interface{}) bool {
+		entry, ok := item.(mapEntry)
+		return !ok || fn(entry.key, entry.val)
+	})
+}
+
+func (m *Map) String() string {
+	strs := make([]string, 0, m.Count())
+	m.Range(func(key, val interface{}) bool {
+		strs = append(strs, fmt.Sprintf("%v %v", key, val))
+		return true
+	})
+	return fmt.Sprintf("{%s}", strings.Join(strs, " "))
+}
+
+func (m *Map) find(key interface{}) *hleaf {
+	if m == nil || m.root == nil {
+		return nil
+	}
+
+	hash, ok := hashKey(key)
+	if !ok {
+		return nil
+	}
+	return m.root.find(hash, key)
+}
+
+// compact rebuilds the map without the holes in the order vector.
+func (m *Map) compact() *Map {
+	res := emptyMap
+	m.Range(func(key, val interface{}) bool {
+		res, _ = res.Assoc(key, val)
+		return true
+	})
+	return res
+}
@@ -0,0 +1,140 @@
+package value_test
+
+import (
+	"testing"
+
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMap(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("Success", func(t *testing.T) {
+		m, err := value.NewMap("b", 1, "a", 2, "b", 3)
+		require.NoError(t, err)
+		assert.Equal(t, 2, m.Count())
+		assert.Equal(t, []interface{}{"b", "a"}, m.Keys())
+
+		val, found := m.Get("b")
+		assert.True(t, found)
+		assert.Equal(t, 3, val)
+	})
+
+	suite.Run("OddForms", func(t *testing.T) {
+		_, err := value.NewMap("a")
+		assert.Error(t, err)
+	})
+
+	suite.Run("UnhashableKey", func(t *testing.T) {
+		_, err := value.NewMap([]int{1}, 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestMap_Updates(suite *testing.T) {
+	suite.Parallel()
+
+	orig, err := value.NewMap("a", 1, "b", 2)
+	require.NoError(suite, err)
+
+	suite.Run("Assoc", func(t *testing.T) {
+		m, err := orig.Assoc("c", 3)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"a", "b", "c"}, m.Keys())
+
+		m, err = m.Assoc("a", 10)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"a", "b", "c"}, m.Keys())
+
+		val, _ := m.Get("a")
+		assert.Equal(t, 10, val)
+
+		_, err = m.Assoc(map[string]int{}, 1)
+		assert.Error(t, err)
+	})
+
+	suite.Run("Dissoc", func(t *testing.T) {
+		m := orig.Dissoc("a")
+		assert.Equal(t, []interface{}{"b"}, m.Keys())
+		assert.True(t, orig == orig.Dissoc("missing"))
+	})
+
+	suite.Run("Unchanged", func(t *testing.T) {
+		assert.Equal(t, []interface{}{"a", "b"}, orig.Keys())
+		val, _ := orig.Get("a")
+		assert.Equal(t, 1, val)
+	})
+
+	suite.Run("NilMap", func(t *testing.T) {
+		var m *value.Map
+		assert.Equal(t, 0, m.Count())
+
+		m, err := m.Assoc("a", 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, m.Count())
+	})
+}
+
+func TestMap_CollectionKeys(t *testing.T) {
+	t.Parallel()
+
+	inner, err := value.NewMap("x", 1)
+	require.NoError(t, err)
+	set, err := value.NewSet(1, 2)
+	require.NoError(t, err)
+
+	m, err := value.NewMap(value.NewVector(1, 2), "vec", inner, "map", set, "set")
+	require.NoError(t, err)
+
+	// equal collections created separately find the same entries.
+	otherInner, _ := value.NewMap("x", 1)
+	otherSet, _ := value.NewSet(2, 1)
+	for key, expected := range map[string]interface{}{"vec": value.NewVector(1, 2), "map": otherInner, "set": otherSet} {
+		val, found := m.Get(expected)
+		assert.True(t, found, key)
+		assert.Equal(t, key, val)
+	}
+
+	m, err = m.Assoc(value.NewVector(1, 2), "updated")
+	require.NoError(t, err)
+	assert.Equal(t, 3, m.Count())
+
+	_, err = value.NewMap(value.NewVector([]int{1}), 1)
+	assert.Error(t, err)
+}
+
+func TestMap_ManyKeys(t *testing.T) {
+	t.Parallel()
+
+	const n = 5000
+
+	m, err := value.NewMap()
+	require.NoError(t, err)
+	for i := 0; i < n; i++ {
+		m, err = m.Assoc(i, i*2)
+		require.NoError(t, err)
+	}
+	require.Equal(t, n, m.Count())
+
+	for i := 0; i < n; i += 2 {
+		m = m.Dissoc(i)
+	}
+	require.Equal(t, n/2, m.Count())
+
+	for i := 0; i < n; i++ {
+		val, found := m.Get(i)
+		if i%2 == 0 {
+			require.False(t, found, i)
+		} else {
+			require.True(t, found, i)
+			require.Equal(t, i*2, val)
+		}
+	}
+
+	keys := m.Keys()
+	require.Len(t, keys, n/2)
+	assert.Equal(t, 1, keys[0])
+	assert.Equal(t, n-1, keys[len(keys)-1])
+}
@@ -0,0 +1,318 @@
+package value
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// Seq is a sequence of items which may be realized lazily. Sequences are
+// immutable: calling Uncons repeatedly returns the same items even if the
+// source of the items (e.g. a Go channel) can be consumed only once.
+type Seq interface {
+	// Uncons returns the first item and the rest of the sequence. ok is
+	// false if the sequence is empty.
+	Uncons() (first interface{}, rest Seq, ok bool, err error)
+}
+
+// ToSeq returns a sequence of the items of the value. Lists and other
+// sequences are returned as is. Vectors, sets, strings (as runes), Go
+// slices and arrays result in their items, maps and Go maps in [key value]
+// vectors. Go channels are received from as the sequence is realized and
+// Go iterator functions (iter.Seq and iter.Seq2 of any type) are pulled
+// from in the same way. nil results in an empty list.
+func ToSeq(v interface{}) (Seq, error) {
+	switch coll := v.(type) {
+	case nil:
+		return emptyList, nil
+
+	case Seq:
+		return coll, nil
+
+	case *Vector:
+		return vectorSeq{vec: coll}, nil
+
+	case *Set:
+		return itemsSeq(coll.Items()), nil
+
+	case *Map:
+		entries := make([]interface{}, 0, coll.Count())
+		coll.Range(func(key, val interface{}) bool {
+			entries = append(entries, NewVector(key, val))
+			return true
+		})
+		return itemsSeq(entries), nil
+
+	case string:
+		runes := []rune(coll)
+		items := make([]interface{}, len(runes))
+		for i, r := range runes {
+			items[i] = r
+		}
+		return itemsSeq(items), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		return reflectSeq{rv: rv}, nil
+
+	case reflect.Map:
+		entries := make([]interface{}, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			entries = append(entries, NewVector(iter.Key().Interface(), iter.Value().Interface()))
+		}
+		return itemsSeq(entries), nil
+
+	case reflect.Chan:
+		if rv.Type().ChanDir()&reflect.RecvDir != 0 {
+			return chanSeq(rv), nil
+		}
+
+	case reflect.Func:
+		if seq, ok := goIterator(rv); ok {
+			return newIterSeq(seq), nil
+		}
+	}
+
+	return nil, fmt.Errorf("value of type '%s' is not a sequence", reflect.TypeOf(v))
+}
+
+// SeqCons returns a sequence with the item in front of the rest.
+func SeqCons(item interface{}, rest Seq) Seq {
+	if list, ok := rest.(*List); ok {
+		return list.Conj(item)
+	}
+	return &consSeq{first: item, rest: rest}
+}
+
+// SeqItems realizes the sequence and returns all the items. Never returns
+// for infinite sequences.
+func SeqItems(seq Seq) ([]interface{}, error) {
+	items := []interface{}{}
+	for {
+		item, rest, ok, err := seq.Uncons()
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return items, nil
+		}
+
+		items = append(items, item)
+		seq = rest
+	}
+}
+
+// LazySeq is a sequence realized by calling a function when the items are
+// first needed. The function is called at most once and the result (or
+// the error) is remembered.
+type LazySeq struct {
+	once sync.Once
+	fn   func() (Seq, error)
+	seq  Seq
+	err  error
+}
+
+// NewLazySeq creates a sequence realized by calling fn. fn may return nil
+// for an empty sequence.
+func NewLazySeq(fn func() (Seq, error)) *LazySeq {
+	return &LazySeq{fn: fn}
+}
+
+// Uncons realizes the sequence if required and returns its first item and
+// the rest.
+func (ls *LazySeq) Uncons() (interface{}, Seq, bool, error) {
+	seq, err := ls.realize()
+	if err != nil || seq == nil {
+		return nil, nil, false, err
+	}
+	return seq.Uncons()
+}
+
+func (ls *LazySeq) realize() (Seq, error) {
+	ls.once.Do(func() {
+		seq, err := ls.fn()
+		// unwrap nested lazy sequences iteratively instead of recursing
+		// through all of them on every Uncons.
+		for err == nil {
+			inner, ok := seq.(*LazySeq)
+			if !ok {
+				break
+			}
+			seq, err = inner.realize()
+		}
+
+		ls.seq, ls.err, ls.fn = seq, err, nil
+	})
+	return ls.seq, ls.err
+}
+
+func (ls *LazySeq) String() string {
+	return seqString(ls)
+}
+
+type consSeq struct {
+	first interface{}
+	rest  Seq
+}
+
+func (cs *consSeq) Uncons() (interface{}, Seq, bool, error) {
+	return cs.first, cs.rest, true, nil
+}
+
+func (cs *consSeq) String() string {
+	return seqString(cs)
+}
+
+type itemsSeq []interface{}
+
+func (is itemsSeq) Uncons() (interface{}, Seq, bool, error) {
+	if len(is) == 0 {
+		return nil, nil, false, nil
+	}
+	return is[0], is[1:], true, nil
+}
+
+func (is itemsSeq) String() string {
+	return seqString(is)
+}
+
+type vectorSeq struct {
+	vec *Vector
+	i   int
+}
+
+func (vs vectorSeq) Uncons() (interface{}, Seq, bool, error) {
+	item, ok := vs.vec.Nth(vs.i)
+	if !ok {
+		return nil, nil, false, nil
+	}
+	return item, vectorSeq{vec: vs.vec, i: vs.i + 1}, true, nil
+}
+
+func (vs vectorSeq) String() string {
+	return seqString(vs)
+}
+
+type reflectSeq struct {
+	rv reflect.Value
+	i  int
+}
+
+func (rs reflectSeq) Uncons() (interface{}, Seq, bool, error) {
+	if rs.i >= rs.rv.Len() {
+		return nil, nil, false, nil
+	}
+	return rs.rv.Index(rs.i).Interface(), reflectSeq{rv: rs.rv, i: rs.i + 1}, true, nil
+}
+
+func (rs reflectSeq) String() string {
+	return seqString(rs)
+}
+
+func chanSeq(ch reflect.Value) Seq {
+	return NewLazySeq(func() (Seq, error) {
+		item, ok := ch.Recv()
+		if !ok {
+			return nil, nil
+		}
+		return &consSeq{first: item.Interface(), rest: chanSeq(ch)}, nil
+	})
+}
+
+// IterSeq is a lazy sequence of the items produced by a Go iterator. The
+// iterator is suspended between items. The code that called ToSeq owns the
+// sequence and should call Stop once done with it if it was not realized
+// till the end. Sequences dropped without calling Stop (e.g. by scripts)
+// are stopped when they become unreachable.
+type IterSeq struct {
+	seq  Seq
+	pull *pullState
+}
+
+func newIterSeq(seq iter.Seq[interface{}]) *IterSeq {
+	next, stop := iter.Pull(seq)
+	ps := &pullState{next: next, stop: stop}
+	runtime.AddCleanup(ps, func(stop func()) { stop() }, stop)
+
+	return &IterSeq{seq: ps.seq(), pull: ps}
+}
+
+// Uncons realizes the first item if required and returns it with the rest.
+func (is *IterSeq) Uncons() (interface{}, Seq, bool, error) {
+	return is.seq.Uncons()
+}
+
+// Stop stops the iterator. Items realized already are still available but
+// the sequence ends after them.
+func (is *IterSeq) Stop() {
+	is.pull.mu.Lock()
+	defer is.pull.mu.Unlock()
+	is.pull.stop()
+}
+
+func (is *IterSeq) String() string {
+	return seqString(is)
+}
+
+// pullState serializes the calls to the functions returned by iter.Pull
+// which must not be called concurrently.
+type pullState struct {
+	mu   sync.Mutex
+	next func() (interface{}, bool)
+	stop func()
+}
+
+func (ps *pullState) seq() Seq {
+	return NewLazySeq(func() (Seq, error) {
+		ps.mu.Lock()
+		item, ok := ps.next()
+		ps.mu.Unlock()
+
+		if !ok {
+			return nil, nil
+		}
+		return &consSeq{first: item, rest: ps.seq()}, nil
+	})
+}
+
+// goIterator adapts Go iterator functions of the form
+// func(yield func(V) bool) and func(yield func(K, V) bool) (i.e., iter.Seq
+// and iter.Seq2). Pairs are produced as [key value] vectors.
+func goIterator(fn reflect.Value) (iter.Seq[interface{}], bool) {
+	ft := fn.Type()
+	if ft.NumIn() != 1 || ft.NumOut() != 0 {
+		return nil, false
+	}
+
+	yt := ft.In(0)
+	if yt.Kind() != reflect.Func || yt.NumOut() != 1 || yt.Out(0).Kind() != reflect.Bool ||
+		(yt.NumIn() != 1 && yt.NumIn() != 2) {
+		return nil, false
+	}
+
+	return func(yield func(interface{}) bool) {
+		yieldFn := reflect.MakeFunc(yt, func(args []reflect.Value) []reflect.Value {
+			var item interface{}
+			if len(args) == 1 {
+				item = args[0].Interface()
+			} else {
+				item = NewVector(args[0].Interface(), args[1].Interface())
+			}
+			return []reflect.Value{reflect.ValueOf(yield(item)).Convert(yt.Out(0))}
+		})
+		fn.Call([]reflect.Value{yieldFn})
+	}, true
+}
+
+// seqString formats the realized sequence like a list.
+func seqString(seq Seq) string {
+	items, err := SeqItems(seq)
+	if err != nil {
+		return fmt.Sprintf("<error: %v>", err)
+	}
+	return NewList(items...).String()
+}
@@ -0,0 +1,227 @@
+package value_test
+
+import (
+	"errors"
+	"maps"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToSeq(suite *testing.T) {
+	suite.Parallel()
+
+	m, err := value.NewMap("a", 1, "b", 2)
+	require.NoError(suite, err)
+	set, err := value.NewSet(1, 2)
+	require.NoError(suite, err)
+
+	cases := map[string]struct {
+		coll  interface{}
+		items []interface{}
+	}{
+		"Nil":       {coll: nil, items: []interface{}{}},
+		"List":      {coll: value.NewList(1, 2), items: []interface{}{1, 2}},
+		"Vector":    {coll: value.NewVector(seq(100)...), items: seq(100)},
+		"Map":       {coll: m, items: []interface{}{value.NewVector("a", 1), value.NewVector("b", 2)}},
+		"Set":       {coll: set, items: []interface{}{1, 2}},
+		"String":    {coll: "héllo", items: []interface{}{'h', 'é', 'l', 'l', 'o'}},
+		"GoSlice":   {coll: []string{"a", "b"}, items: []interface{}{"a", "b"}},
+		"GoArray":   {coll: [2]int{1, 2}, items: []interface{}{1, 2}},
+		"GoMap":     {coll: map[string]int{"a": 1}, items: []interface{}{value.NewVector("a", 1)}},
+		"GoIter":    {coll: slices.Values([]int{1, 2, 3}), items: []interface{}{1, 2, 3}},
+		"GoIterKV":  {coll: maps.All(map[string]int{"k": 1}), items: []interface{}{value.NewVector("k", 1)}},
+		"LazySeq":   {coll: value.TakeSeq(2, value.NewList(1, 2, 3)), items: []interface{}{1, 2}},
+		"EmptyList": {coll: value.NewList(), items: []interface{}{}},
+	}
+
+	for name, cs := range cases {
+		cs := cs
+		suite.Run(name, func(t *testing.T) {
+			s, err := value.ToSeq(cs.coll)
+			require.NoError(t, err)
+
+			items, err := value.SeqItems(s)
+			require.NoError(t, err)
+			assert.True(t, value.Equal(value.NewList(cs.items...), s), "%v", items)
+			assert.Equal(t, cs.items, items)
+		})
+	}
+
+	suite.Run("NotASeq", func(t *testing.T) {
+		for _, v := range []interface{}{10, true, func(a, b int) {}, make(chan<- int)} {
+			_, err := value.ToSeq(v)
+			assert.Error(t, err, "%T", v)
+		}
+	})
+}
+
+func TestToSeq_Channel(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan int)
+	go func() {
+		for i := 0; i < 3; i++ {
+			ch <- i
+		}
+		close(ch)
+	}()
+
+	s, err := value.ToSeq(ch)
+	require.NoError(t, err)
+
+	// the items received once are remembered.
+	for i := 0; i < 2; i++ {
+		items, err := value.SeqItems(s)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{0, 1, 2}, items)
+	}
+}
+
+func TestToSeq_InfiniteIterator(t *testing.T) {
+	t.Parallel()
+
+	naturals := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	s, err := value.ToSeq(naturals)
+	require.NoError(t, err)
+
+	items, err := value.SeqItems(value.TakeSeq(5, value.DropSeq(10, s)))
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{10, 11, 12, 13, 14}, items)
+}
+
+func TestToSeq_StopIterator(suite *testing.T) {
+	suite.Parallel()
+
+	// naturals returns an infinite iterator which closes done once it
+	// returns.
+	naturals := func(done chan struct{}) func(yield func(int) bool) {
+		return func(yield func(int) bool) {
+			defer close(done)
+			for i := 0; ; i++ {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+	}
+
+	suite.Run("Stop", func(t *testing.T) {
+		done := make(chan struct{})
+		s, err := value.ToSeq(naturals(done))
+		require.NoError(t, err)
+
+		first, rest, ok, err := s.Uncons()
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, 0, first)
+
+		s.(*value.IterSeq).Stop()
+		<-done
+
+		// realized items are still available but nothing more.
+		items, err := value.SeqItems(s)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{0}, items)
+
+		_, _, ok, err = rest.Uncons()
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	suite.Run("Unreachable", func(t *testing.T) {
+		done := make(chan struct{})
+		func() {
+			s, err := value.ToSeq(naturals(done))
+			require.NoError(t, err)
+			_, _, _, err = s.Uncons()
+			require.NoError(t, err)
+		}()
+
+		timeout := time.After(5 * time.Second)
+		for {
+			runtime.GC()
+			select {
+			case <-done:
+				return
+			case <-timeout:
+				t.Fatal("iterator was not stopped")
+			case <-time.After(10 * time.Millisecond):
+			}
+		}
+	})
+}
+
+func TestSeqCons(t *testing.T) {
+	t.Parallel()
+
+	list := value.SeqCons(0, value.NewList(1))
+	assert.IsType(t, &value.List{}, list)
+
+	s := value.SeqCons(0, value.TakeSeq(2, value.NewList(1, 2, 3)))
+	items, err := value.SeqItems(s)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{0, 1, 2}, items)
+	assert.Equal(t, "(0 1 2)", s.(interface{ String() string }).String())
+}
+
+func TestLazySeq(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("RealizedOnce", func(t *testing.T) {
+		calls := 0
+		s := value.NewLazySeq(func() (value.Seq, error) {
+			calls++
+			return value.NewList(1), nil
+		})
+		assert.Equal(t, 0, calls)
+
+		for i := 0; i < 3; i++ {
+			first, _, ok, err := s.Uncons()
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, 1, first)
+		}
+		assert.Equal(t, 1, calls)
+	})
+
+	suite.Run("Empty", func(t *testing.T) {
+		s := value.NewLazySeq(func() (value.Seq, error) { return nil, nil })
+		_, _, ok, err := s.Uncons()
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	suite.Run("Error", func(t *testing.T) {
+		s := value.NewLazySeq(func() (value.Seq, error) { return nil, errors.New("failed") })
+		_, _, _, err := s.Uncons()
+		assert.EqualError(t, err, "failed")
+
+		_, err = value.SeqItems(value.SeqCons(1, s))
+		assert.EqualError(t, err, "failed")
+	})
+
+	suite.Run("Nested", func(t *testing.T) {
+		var s value.Seq = value.NewList(1)
+		for i := 0; i < 100000; i++ {
+			inner := s
+			s = value.NewLazySeq(func() (value.Seq, error) { return inner, nil })
+		}
+
+		items, err := value.SeqItems(s)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{1}, items)
+	})
+}
@@ -0,0 +1,102 @@
+package value
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Set is a persistent (immutable) set that remembers the order in which
+// the items were added. Items can be of the same types as the keys of a
+// Map. A nil or zero Set is an empty set.
+type Set struct {
+	items *Map
+}
+
+var emptySet = &Set{items: emptyMap}
+
+// NewSet creates a set with the given items. Duplicate items are added
+// only once. Returns error if an item is not hashable.
+func NewSet(items ...interface{}) (*Set, error) {
+	set := emptySet
+	for _, item := range items {
+		var err error
+		if set, err = set.Conj(item); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// Count returns the number of items in the set.
+func (set *Set) Count() int {
+	if set == nil {
+		return 0
+	}
+	return set.items.Count()
+}
+
+// Contains returns true if the item is in the set.
+func (set *Set) Contains(item interface{}) bool {
+	if set == nil {
+		return false
+	}
+
+	_, found := set.items.Get(item)
+	return found
+}
+
+// Conj returns a new set with the item added.
+func (set *Set) Conj(item interface{}) (*Set, error) {
+	if set.Contains(item) {
+		return set, nil
+	}
+
+	var items *Map
+	if set != nil {
+		items = set.items
+	}
+
+	items, err := items.Assoc(item, nil)
+	if err != nil {
+		return nil, fmt.Errorf("value of type '%s' cannot be a set item", reflect.TypeOf(item))
+	}
+	return &Set{items: items}, nil
+}
+
+// Disj returns a new set without the item.
+func (set *Set) Disj(item interface{}) *Set {
+	if !set.Contains(item) {
+		return set
+	}
+	return &Set{items: set.items.Dissoc(item)}
+}
+
+// Range calls fn for every item in the order they were added until fn
+// returns false.
+func (set *Set) Range(fn func(item interface{}) bool) {
+	if set == nil {
+		return
+	}
+
+	set.items.Range(func(key, _ interface{}) bool {
+		return fn(key)
+	})
+}
+
+// Items returns the items of the set as a new slice.
+func (set *Set) Items() []interface{} {
+	if set == nil {
+		return []interface{}{}
+	}
+	return set.items.Keys()
+}
+
+func (set *Set) String() string {
+	strs := make([]string, 0, set.Count())
+	set.Range(func(item interface{}) bool {
+		strs = append(strs, fmt.Sprint(item))
+		return true
+	})
+	return fmt.Sprintf("#{%s}", strings.Join(strs, " "))
+}
@@ -0,0 +1,64 @@
+package value_test
+
+import (
+	"testing"
+
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSet(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("Success", func(t *testing.T) {
+		set, err := value.NewSet("b", 1, "a", 1, "b")
+		require.NoError(t, err)
+		assert.Equal(t, 3, set.Count())
+		assert.Equal(t, []interface{}{"b", 1, "a"}, set.Items())
+		assert.True(t, set.Contains(1))
+		assert.False(t, set.Contains(2))
+	})
+
+	suite.Run("UnhashableItem", func(t *testing.T) {
+		_, err := value.NewSet([]int{1})
+		assert.EqualError(t, err, "value of type '[]int' cannot be a set item")
+	})
+}
+
+func TestSet_Updates(suite *testing.T) {
+	suite.Parallel()
+
+	orig, err := value.NewSet(1, 2)
+	require.NoError(suite, err)
+
+	suite.Run("Conj", func(t *testing.T) {
+		set, err := orig.Conj(3)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{1, 2, 3}, set.Items())
+
+		same, err := set.Conj(1)
+		require.NoError(t, err)
+		assert.True(t, same == set)
+	})
+
+	suite.Run("Disj", func(t *testing.T) {
+		assert.Equal(t, []interface{}{2}, orig.Disj(1).Items())
+		assert.True(t, orig == orig.Disj(10))
+	})
+
+	suite.Run("Unchanged", func(t *testing.T) {
+		assert.Equal(t, []interface{}{1, 2}, orig.Items())
+	})
+
+	suite.Run("NilSet", func(t *testing.T) {
+		var set *value.Set
+		assert.Equal(t, 0, set.Count())
+		assert.False(t, set.Contains(1))
+		assert.Equal(t, "#{}", set.String())
+
+		set, err := set.Conj(1)
+		require.NoError(t, err)
+		assert.Equal(t, "#{1}", set.String())
+	})
+}
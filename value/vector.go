@@ -0,0 +1,301 @@
+package value
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	nodeBits  = 5
+	nodeWidth = 1 << nodeBits
+	nodeMask  = nodeWidth - 1
+)
+
+// ErrEmptyVector is returned when popping from an empty vector.
+var ErrEmptyVector = errors.New("cannot pop an empty vector")
+
+// Vector is a persistent (immutable) vector. Updates return a new vector
+// which shares most of its structure with the original, so adding or
+// updating an item takes O(log32 n) time and space. A nil or zero Vector
+// is an empty vector.
+//
+// Items are stored in a tree of nodes with 32 children each and the last
+// (up to 32) items are kept in a separate tail for fast appends.
+type Vector struct {
+	count int
+	shift uint
+	root  *vnode
+	tail  []interface{}
+}
+
+// vnode is a node in the vector tree. Slots of leaf nodes contain the
+// items, slots of other nodes contain the child nodes.
+type vnode struct {
+	slots []interface{}
+}
+
+var emptyVector = &Vector{shift: nodeBits, root: &vnode{}}
+
+// NewVector creates a vector with the given items.
+func NewVector(items ...interface{}) *Vector {
+	vec := emptyVector
+	for len(items) > 0 {
+		// fill the tail in chunks instead of one item at a time.
+		room := nodeWidth - (vec.count - vec.tailOffset())
+		if vec.count > 0 && room == 0 {
+			vec = vec.Conj(items[0])
+			items = items[1:]
+			continue
+		}
+
+		n := room
+		if n > len(items) {
+			n = len(items)
+		}
+
+		tail := make([]interface{}, len(vec.tail), len(vec.tail)+n)
+		copy(tail, vec.tail)
+		vec = &Vector{
+			count: vec.count + n,
+			shift: vec.shift,
+			root:  vec.root,
+			tail:  append(tail, items[:n]...),
+		}
+		items = items[n:]
+	}
+	return vec
+}
+
+// Count returns the number of items in the vector.
+func (vec *Vector) Count() int {
+	if vec == nil {
+		return 0
+	}
+	return vec.count
+}
+
+// Nth returns the item at index i and true if the index is valid.
+func (vec *Vector) Nth(i int) (interface{}, bool) {
+	if i < 0 || i >= vec.Count() {
+		return nil, false
+	}
+	return vec.leafFor(i)[i&nodeMask], true
+}
+
+// Conj returns a new vector with the item added at the end.
+func (vec *Vector) Conj(item interface{}) *Vector {
+	if vec == nil || vec.root == nil {
+		vec = emptyVector
+	}
+
+	if vec.count-vec.tailOffset() < nodeWidth {
+		tail := make([]interface{}, len(vec.tail), len(vec.tail)+1)
+		copy(tail, vec.tail)
+		return &Vector{
+			count: vec.count + 1,
+			shift: vec.shift,
+			root:  vec.root,
+			tail:  append(tail, item),
+		}
+	}
+
+	// tail is full. push it into the tree and start a new tail.
+	leaf := &vnode{slots: vec.tail}
+	shift := vec.shift
+	var root *vnode
+	if (vec.count >> nodeBits) > (1 << vec.shift) {
+		// tree is full, add a new level.
+		root = &vnode{slots: []interface{}{vec.root, newPath(vec.shift, leaf)}}
+		shift += nodeBits
+	} else {
+		root = vec.pushTail(vec.shift, vec.root, leaf)
+	}
+
+	return &Vector{
+		count: vec.count + 1,
+		shift: shift,
+		root:  root,
+		tail:  []interface{}{item},
+	}
+}
+
+// Assoc returns a new vector with the item at index i replaced. Index
+// equal to the count appends the item.
+func (vec *Vector) Assoc(i int, item interface{}) (*Vector, error) {
+	count := vec.Count()
+	if i == count {
+		return vec.Conj(item), nil
+	} else if i < 0 || i > count {
+		return nil, fmt.Errorf("index %d out of bounds for vector of size %d", i, count)
+	}
+
+	if i >= vec.tailOffset() {
+		tail := append([]interface{}(nil), vec.tail...)
+		tail[i&nodeMask] = item
+		return &Vector{count: vec.count, shift: vec.shift, root: vec.root, tail: tail}, nil
+	}
+
+	return &Vector{
+		count: vec.count,
+		shift: vec.shift,
+		root:  assocNode(vec.shift, vec.root, i, item),
+		tail:  vec.tail,
+	}, nil
+}
+
+// Pop returns a new vector without the last item.
+func (vec *Vector) Pop() (*Vector, error) {
+	switch vec.Count() {
+	case 0:
+		return nil, ErrEmptyVector
+
+	case 1:
+		return emptyVector, nil
+	}
+
+	if n := len(vec.tail) - 1; n > 0 {
+		return &Vector{
+			count: vec.count - 1,
+			shift: vec.shift,
+			root:  vec.root,
+			tail:  vec.tail[:n:n],
+		}, nil
+	}
+
+	// tail has a single item. last leaf of the tree becomes the tail.
+	tail := vec.leafFor(vec.count - 2)
+	root := vec.popTail(vec.shift, vec.root)
+	shift := vec.shift
+	if root == nil {
+		root = emptyVector.root
+	}
+	if shift > nodeBits && len(root.slots) == 1 {
+		root = root.slots[0].(*vnode)
+		shift -= nodeBits
+	}
+
+	return &Vector{count: vec.count - 1, shift: shift, root: root, tail: tail}, nil
+}
+
+// Range calls fn for every item in order until fn returns false.
+func (vec *Vector) Range(fn func(i int, item interface{}) bool) {
+	for i := 0; i < vec.Count(); i += nodeWidth {
+		for j, item := range vec.leafFor(i) {
+			if !fn(i+j, item) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the items of the vector as a new slice.
+func (vec *Vector) Slice() []interface{} {
+	items := make([]interface{}, 0, vec.Count())
+	vec.Range(func(_ int, item interface{}) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}
+
+func (vec *Vector) String() string {
+	strs := make([]string, 0, vec.Count())
+	vec.Range(func(_ int, item interface{}) bool {
+		strs = append(strs, fmt.Sprint(item))
+		return true
+	})
+	return fmt.Sprintf("[%s]", strings.Join(strs, " "))
+}
+
+// tailOffset returns the index of the first item in the tail.
+func (vec *Vector) tailOffset() int {
+	if vec.count < nodeWidth {
+		return 0
+	}
+	return ((vec.count - 1) >> nodeBits) << nodeBits
+}
+
+// leafFor returns the leaf (or the tail) containing the item at index i.
+func (vec *Vector) leafFor(i int) []interface{} {
+	if i >= vec.tailOffset() {
+		return vec.tail
+	}
+
+	node := vec.root
+	for level := vec.shift; level > 0; level -= nodeBits {
+		node = node.slots[(i>>level)&nodeMask].(*vnode)
+	}
+	return node.slots
+}
+
+func (vec *Vector) pushTail(level uint, parent, leaf *vnode) *vnode {
+	idx := ((vec.count - 1) >> level) & nodeMask
+	res := parent.clone()
+
+	var child interface{} = leaf
+	if level > nodeBits {
+		if idx < len(parent.slots) {
+			child = vec.pushTail(level-nodeBits, parent.slots[idx].(*vnode), leaf)
+		} else {
+			child = newPath(level-nodeBits, leaf)
+		}
+	}
+
+	if idx < len(res.slots) {
+		res.slots[idx] = child
+	} else {
+		res.slots = append(res.slots, child)
+	}
+	return res
+}
+
+// popTail removes the last leaf from the tree. Returns nil if the node
+// becomes empty.
+func (vec *Vector) popTail(level uint, node *vnode) *vnode {
+	idx := ((vec.count - 2) >> level) & nodeMask
+	if level > nodeBits {
+		child := vec.popTail(level-nodeBits, node.slots[idx].(*vnode))
+		if child == nil && idx == 0 {
+			return nil
+		}
+
+		res := node.clone()
+		if child == nil {
+			res.slots = res.slots[:idx]
+		} else {
+			res.slots[idx] = child
+		}
+		return res
+	} else if idx == 0 {
+		return nil
+	}
+
+	res := node.clone()
+	res.slots = res.slots[:idx]
+	return res
+}
+
+func (node *vnode) clone() *vnode {
+	return &vnode{slots: append(make([]interface{}, 0, nodeWidth), node.slots...)}
+}
+
+// newPath creates a path of nodes from the given level down to the leaf.
+func newPath(level uint, leaf *vnode) *vnode {
+	if level == 0 {
+		return leaf
+	}
+	return &vnode{slots: []interface{}{newPath(level-nodeBits, leaf)}}
+}
+
+func assocNode(level uint, node *vnode, i int, item interface{}) *vnode {
+	res := node.clone()
+	if level == 0 {
+		res.slots[i&nodeMask] = item
+		return res
+	}
+
+	idx := (i >> level) & nodeMask
+	res.slots[idx] = assocNode(level-nodeBits, node.slots[idx].(*vnode), i, item)
+	return res
+}
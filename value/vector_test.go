@@ -0,0 +1,168 @@
+package value_test
+
+import (
+	"testing"
+
+	"github.com/spy16/parens/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVector(suite *testing.T) {
+	suite.Parallel()
+
+	// sizes around the tail and tree level boundaries.
+	for _, n := range []int{0, 1, 31, 32, 33, 64, 1024, 1056, 1057, 32*32*32 + 33} {
+		items := seq(n)
+		vec := value.NewVector(items...)
+		require.Equal(suite, n, vec.Count())
+		assert.Equal(suite, items, vec.Slice())
+
+		_, found := vec.Nth(n)
+		assert.False(suite, found)
+		_, found = vec.Nth(-1)
+		assert.False(suite, found)
+	}
+}
+
+func TestVector_Conj(suite *testing.T) {
+	suite.Parallel()
+
+	vec := value.NewVector()
+	for i := 0; i < 40000; i++ {
+		vec = vec.Conj(i)
+	}
+	require.Equal(suite, 40000, vec.Count())
+
+	for _, i := range []int{0, 31, 32, 1023, 1024, 32767, 32768, 39999} {
+		item, found := vec.Nth(i)
+		assert.True(suite, found)
+		assert.Equal(suite, i, item)
+	}
+}
+
+func TestVector_Assoc(suite *testing.T) {
+	suite.Parallel()
+
+	orig := value.NewVector(seq(2000)...)
+
+	suite.Run("Update", func(t *testing.T) {
+		vec := orig
+		for _, i := range []int{0, 31, 32, 1000, 1999} {
+			var err error
+			vec, err = vec.Assoc(i, "x")
+			require.NoError(t, err)
+
+			item, _ := vec.Nth(i)
+			assert.Equal(t, "x", item)
+		}
+		assert.Equal(t, 2000, vec.Count())
+
+		item, _ := vec.Nth(1)
+		assert.Equal(t, 1, item)
+	})
+
+	suite.Run("Append", func(t *testing.T) {
+		vec, err := orig.Assoc(2000, "x")
+		require.NoError(t, err)
+		assert.Equal(t, 2001, vec.Count())
+	})
+
+	suite.Run("OutOfBounds", func(t *testing.T) {
+		_, err := orig.Assoc(2001, "x")
+		assert.Error(t, err)
+
+		_, err = orig.Assoc(-1, "x")
+		assert.Error(t, err)
+	})
+
+	suite.Run("Unchanged", func(t *testing.T) {
+		assert.Equal(t, seq(2000), orig.Slice())
+	})
+}
+
+func TestVector_Pop(suite *testing.T) {
+	suite.Parallel()
+
+	suite.Run("AllTheWay", func(t *testing.T) {
+		n := 32*32 + 70
+		vec := value.NewVector(seq(n)...)
+		for n > 0 {
+			var err error
+			vec, err = vec.Pop()
+			require.NoError(t, err)
+			n--
+
+			require.Equal(t, n, vec.Count())
+			if n > 0 {
+				last, _ := vec.Nth(n - 1)
+				require.Equal(t, n-1, last)
+			}
+		}
+
+		_, err := vec.Pop()
+		assert.Equal(t, value.ErrEmptyVector, err)
+	})
+
+	suite.Run("ConjAfterPop", func(t *testing.T) {
+		vec := value.NewVector(seq(33)...)
+		vec, err := vec.Pop()
+		require.NoError(t, err)
+		vec, err = vec.Pop()
+		require.NoError(t, err)
+
+		vec = vec.Conj("a").Conj("b")
+		assert.Equal(t, append(seq(31), "a", "b"), vec.Slice())
+	})
+
+	suite.Run("Unchanged", func(t *testing.T) {
+		orig := value.NewVector(seq(1025)...)
+		_, err := orig.Pop()
+		require.NoError(t, err)
+		assert.Equal(t, seq(1025), orig.Slice())
+	})
+}
+
+func TestVector_Shared(t *testing.T) {
+	t.Parallel()
+
+	base := value.NewVector(seq(100)...)
+	a := base.Conj("a")
+	b := base.Conj("b")
+
+	last, _ := a.Nth(100)
+	assert.Equal(t, "a", last)
+	last, _ = b.Nth(100)
+	assert.Equal(t, "b", last)
+	assert.Equal(t, 100, base.Count())
+}
+
+func TestVector_Range(t *testing.T) {
+	t.Parallel()
+
+	var items []interface{}
+	value.NewVector(seq(100)...).Range(func(i int, item interface{}) bool {
+		items = append(items, item)
+		return i < 40
+	})
+	assert.Equal(t, seq(41), items)
+}
+
+func TestVector_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "[]", value.NewVector().String())
+	assert.Equal(t, "[1 a [2]]", value.NewVector(1, "a", value.NewVector(2)).String())
+
+	var vec *value.Vector
+	assert.Equal(t, "[]", vec.String())
+	assert.Equal(t, 1, vec.Conj(1).Count())
+}
+
+func seq(n int) []interface{} {
+	items := make([]interface{}, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}